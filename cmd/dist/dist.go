@@ -20,10 +20,15 @@
 //	⠠⠤⠤⠤⠤⠴⠒⠋⠀⠀⠀⠀⠀⠀⠀⠀⠀⠀⠀⠀⠀⠀⠀⠀⠀⠀⠀⠀⠀⠀⠀⠀⠈⠑⠲⠤⠤⠤⠤⠤⠤⠤⠤⠤⠤⠤⠤⠤⠤⠤⠤⠤⠤⠴⠒⠋⠉⠉⠀⠀⠉⠉⠙⠒⠦⠤⠤⠤⠤⠄⠧ 0.0
 //	⠈⠉⠉⠉⠉⠙⠉⠉⠉⠉⠉⠉⠉⠉⠉⠉⠉⠉⠉⠉⠉⠉⠉⠉⠉⠉⠉⠉⠉⠉⠉⠉⠋⠉⠉⠉⠉⠉⠉⠉⠉⠉⠉⠉⠉⠉⠉⠉⠉⠉⠉⠉⠉⠉⠉⠉⠉⠉⠉⠋⠉⠉⠉⠉⠉⠉⠉⠉⠉⠁
 //	     0                         10                         20
+//
+// The -q flag selects the definition of the sample quantile, such as
+// R7 (the default in R and NumPy) or HarrellDavis. See
+// stats.QuantileMethod.
 package main
 
 import (
 	"bufio"
+	"flag"
 	"fmt"
 	"io"
 	"math"
@@ -35,6 +40,14 @@ import (
 )
 
 func main() {
+	flagQ := flag.String("q", "R8", "compute quantiles using `method`, one of R1-R9 or HarrellDavis")
+	flag.Parse()
+	method, ok := parseQuantileMethod(*flagQ)
+	if !ok {
+		fmt.Fprintf(os.Stderr, "unknown quantile method %q\n", *flagQ)
+		os.Exit(2)
+	}
+
 	s := readInput(os.Stdin)
 	if len(s.Xs) == 0 {
 		fmt.Fprintln(os.Stderr, "no input")
@@ -57,7 +70,7 @@ func main() {
 		if !ok {
 			label = fmt.Sprintf("%d%%ile", p)
 		}
-		fmt.Printf("%8s %.6g\n", label, s.Quantile(float64(p)/100))
+		fmt.Printf("%8s %.6g\n", label, s.QuantileWith(float64(p)/100, method))
 	}
 	fmt.Println()
 
@@ -66,6 +79,17 @@ func main() {
 	FprintPDF(os.Stdout, kde)
 }
 
+// parseQuantileMethod returns the stats.QuantileMethod named name,
+// without its "Quantile" prefix.
+func parseQuantileMethod(name string) (stats.QuantileMethod, bool) {
+	for m := stats.QuantileR1; m <= stats.QuantileHarrellDavis; m++ {
+		if strings.EqualFold("Quantile"+name, m.String()) {
+			return m, true
+		}
+	}
+	return 0, false
+}
+
 func readInput(r io.Reader) (sample stats.Sample) {
 	scanner := bufio.NewScanner(r)
 	for scanner.Scan() {
@@ -34,7 +34,11 @@ const (
 // stacked vertically and on the same X axis (but possibly different Y
 // axes).
 func FprintPDF(w io.Writer, dists ...stats.Dist) error {
-	xscale, xs := commonScale(dists...)
+	common := make([]stats.DistCommon, len(dists))
+	for i, d := range dists {
+		common[i] = d
+	}
+	xscale, xs := commonScale(common...)
 	for _, d := range dists {
 		if err := fprintFn(w, d.PDF, xscale, xs); err != nil {
 			return err
@@ -44,8 +48,9 @@ func FprintPDF(w io.Writer, dists ...stats.Dist) error {
 }
 
 // FprintCDF is equivalent to FprintPDF, but prints the CDF of each
-// distribution.
-func FprintCDF(w io.Writer, dists ...stats.Dist) error {
+// distribution. Since this only requires the CDF, it also accepts
+// distributions without a PDF, such as stats.ECDF.
+func FprintCDF(w io.Writer, dists ...stats.DistCommon) error {
 	xscale, xs := commonScale(dists...)
 	for _, d := range dists {
 		if err := fprintFn(w, d.CDF, xscale, xs); err != nil {
@@ -63,7 +68,7 @@ func makeScale(x1, x2 float64, y1, y2 int) scale.QQ {
 	}
 }
 
-func commonScale(dist ...stats.Dist) (xscale scale.QQ, xs []float64) {
+func commonScale(dist ...stats.DistCommon) (xscale scale.QQ, xs []float64) {
 	var l, h float64
 	if len(dist) == 0 {
 		l, h = -1, 1
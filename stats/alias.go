@@ -0,0 +1,102 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package stats
+
+import (
+	"math"
+	"math/rand"
+)
+
+// An AliasSampler draws random values from a discrete distribution
+// with finite support in constant time using Walker's alias method.
+// Constructing an AliasSampler takes time linear in the number of
+// points in the support, so it is worthwhile when drawing many
+// values from the same distribution.
+type AliasSampler struct {
+	// k0 is the index of the first point of the support in
+	// units of step.
+	k0   int
+	step float64
+
+	// prob[i] is the probability of choosing point i once
+	// column i has been chosen. Otherwise, the sampler returns
+	// point alias[i].
+	prob  []float64
+	alias []int
+}
+
+// NewAliasSampler returns an AliasSampler for dist. dist must have
+// finite support; that is, its Bounds must be exact.
+//
+// NewAliasSampler panics if dist does not have finite support.
+func NewAliasSampler(dist DiscreteDist) *AliasSampler {
+	step := dist.Step()
+	lo, hi := dist.Bounds()
+	if dist.CDF(lo-step) != 0 || dist.CDF(hi) != 1 {
+		panic("NewAliasSampler requires a distribution with finite support")
+	}
+	k0 := int(math.Round(lo / step))
+	n := int(math.Round(hi/step)) - k0 + 1
+
+	// Scale the PMF so the average column has height 1.
+	prob := make([]float64, n)
+	total := 0.0
+	for i := range prob {
+		prob[i] = dist.PMF(float64(k0+i) * step)
+		total += prob[i]
+	}
+	for i := range prob {
+		prob[i] *= float64(n) / total
+	}
+
+	// Vose's algorithm: fill each short column with the excess of
+	// a tall column.
+	alias := make([]int, n)
+	var small, large []int
+	for i, p := range prob {
+		alias[i] = i
+		if p < 1 {
+			small = append(small, i)
+		} else {
+			large = append(large, i)
+		}
+	}
+	for len(small) > 0 && len(large) > 0 {
+		s, l := small[len(small)-1], large[len(large)-1]
+		small = small[:len(small)-1]
+		alias[s] = l
+		prob[l] -= 1 - prob[s]
+		if prob[l] < 1 {
+			large = large[:len(large)-1]
+			small = append(small, l)
+		}
+	}
+	// Any remaining columns are full, up to rounding error.
+	for _, i := range large {
+		prob[i] = 1
+	}
+	for _, i := range small {
+		prob[i] = 1
+	}
+
+	return &AliasSampler{k0, step, prob, alias}
+}
+
+// Rand returns a random value drawn from the distribution. It takes
+// an optional source of randomness; if this is nil, it uses the
+// default global source.
+func (a *AliasSampler) Rand(r *rand.Rand) float64 {
+	var i int
+	var u float64
+	if r == nil {
+		i, u = rand.Intn(len(a.prob)), rand.Float64()
+	} else {
+		i, u = r.Intn(len(a.prob)), r.Float64()
+	}
+	if u >= a.prob[i] {
+		i = a.alias[i]
+	}
+	return float64(a.k0+i) * a.step
+}
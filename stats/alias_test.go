@@ -0,0 +1,47 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package stats
+
+import (
+	"math/rand"
+	"testing"
+)
+
+func TestAliasSampler(t *testing.T) {
+	for _, dist := range []DiscreteDist{
+		BinomialDist{N: 10, P: 0.3},
+		UDist{N1: 3, N2: 4, T: []int{2, 1, 1, 2, 1}},
+		BinomialDist{N: 0, P: 0.5},
+	} {
+		a := NewAliasSampler(dist)
+		r := rand.New(rand.NewSource(1))
+		const n = 100000
+		counts := make(map[float64]int)
+		for i := 0; i < n; i++ {
+			counts[a.Rand(r)]++
+		}
+		for x := range counts {
+			if dist.PMF(x) == 0 {
+				t.Errorf("%v: sampled %v, which is not in the support", dist, x)
+			}
+		}
+		l, h := dist.Bounds()
+		for x := l; x <= h; x += dist.Step() {
+			want := dist.PMF(x)
+			if got := float64(counts[x]) / n; !aeqTol(got, want, 0.01) {
+				t.Errorf("%v: frequency of %v is %v, want %v", dist, x, got, want)
+			}
+		}
+	}
+}
+
+func TestAliasSamplerInfinite(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Error("NewAliasSampler of infinite support did not panic")
+		}
+	}()
+	NewAliasSampler(geometricDist{0.5})
+}
@@ -0,0 +1,280 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package stats
+
+import "math"
+
+// This file implements statistics of time series, such as successive
+// iterations of a benchmark, whose values may be autocorrelated. For
+// autocorrelated data, the standard error of the mean is not
+// σ/√n, so confidence intervals that assume independent values, like
+// MeanCI, may be much too narrow.
+
+// Autocorrelation returns the sample autocorrelation function of the
+// series xs at lags 0 through maxLag. That is, acf[k] is the
+// correlation between xs[i] and xs[i+k]. acf[0] is always 1. If maxLag
+// is greater than len(xs)-1, it is reduced to len(xs)-1.
+//
+// This uses the standard biased estimator of the autocovariance,
+// which divides by len(xs) at every lag. If xs has zero variance,
+// acf[k] is NaN for k > 0.
+func Autocorrelation(xs []float64, maxLag int) []float64 {
+	if len(xs) == 0 || maxLag < 0 {
+		return nil
+	}
+	maxLag = minint(maxLag, len(xs)-1)
+	ac := newAutocov(xs)
+	acf := make([]float64, maxLag+1)
+	acf[0] = 1
+	for k := 1; k < len(acf); k++ {
+		acf[k] = ac.at(k) / ac.at(0)
+	}
+	return acf
+}
+
+// PartialAutocorrelation returns the sample partial autocorrelation
+// function of the series xs at lags 0 through maxLag. That is,
+// pacf[k] is the correlation between xs[i] and xs[i+k] after removing
+// the linear effect of the values between them. pacf[0] is always 1.
+// If maxLag is greater than len(xs)-1, it is reduced to len(xs)-1.
+//
+// For an autoregressive process of order p, pacf[k] is approximately 0
+// for k > p, so this is useful for choosing the order of an AR model.
+//
+// This computes the partial autocorrelations from Autocorrelation
+// using the Durbin-Levinson recursion.
+func PartialAutocorrelation(xs []float64, maxLag int) []float64 {
+	return pacfFromACF(Autocorrelation(xs, maxLag))
+}
+
+// pacfFromACF returns the partial autocorrelation function
+// corresponding to the autocorrelation function acf.
+func pacfFromACF(acf []float64) []float64 {
+	if len(acf) == 0 {
+		return nil
+	}
+	pacf := make([]float64, len(acf))
+	pacf[0] = 1
+	// phi[j] are the coefficients of the AR(k) model that best
+	// predicts xs[i] from xs[i-1], ..., xs[i-k].
+	phi := make([]float64, len(acf))
+	prev := make([]float64, len(acf))
+	for k := 1; k < len(acf); k++ {
+		num, den := acf[k], 1.0
+		for j := 1; j < k; j++ {
+			num -= prev[j] * acf[k-j]
+			den -= prev[j] * acf[j]
+		}
+		phi[k] = num / den
+		for j := 1; j < k; j++ {
+			phi[j] = prev[j] - phi[k]*prev[k-j]
+		}
+		pacf[k] = phi[k]
+		copy(prev, phi)
+	}
+	return pacf
+}
+
+// autocov computes the autocovariances of a series on demand.
+type autocov struct {
+	xs   []float64
+	mean float64
+	memo []float64
+}
+
+func newAutocov(xs []float64) *autocov {
+	return &autocov{xs: xs, mean: Mean(xs)}
+}
+
+// at returns the biased sample autocovariance at lag k.
+func (a *autocov) at(k int) float64 {
+	for len(a.memo) <= k {
+		lag := len(a.memo)
+		sum := 0.0
+		for i := 0; i+lag < len(a.xs); i++ {
+			sum += (a.xs[i] - a.mean) * (a.xs[i+lag] - a.mean)
+		}
+		a.memo = append(a.memo, sum/float64(len(a.xs)))
+	}
+	return a.memo[k]
+}
+
+// EffectiveSampleSize returns the effective sample size of the series
+// xs. This is the number of independent values whose mean has the
+// same variance as the mean of xs. For positively autocorrelated
+// series, this is less than len(xs). For negatively autocorrelated
+// series, it may be greater than len(xs), up to len(xs)·log10(len(xs)).
+//
+// This is len(xs)/τ, where τ = 1 + 2 Σ acf[k] is the integrated
+// autocorrelation time. It estimates τ using Geyer's initial monotone
+// sequence estimator, which truncates the sum once the sums of
+// adjacent pairs of autocorrelations stop being positive and
+// decreasing. This takes O(n·K) time, where K is the lag at which the
+// sum is truncated.
+//
+// If xs has fewer than 2 values, this returns len(xs). If xs has zero
+// variance, this returns NaN.
+//
+// Geyer, Charles J. (1992). "Practical Markov Chain Monte Carlo".
+// Statistical Science 7 (4): 473–483.
+func EffectiveSampleSize(xs []float64) float64 {
+	n := len(xs)
+	if n < 2 {
+		return float64(n)
+	}
+	ac := newAutocov(xs)
+	g0 := ac.at(0)
+	if g0 == 0 {
+		return nan
+	}
+	// Sum Γ_m = acf[2m] + acf[2m+1] while it is positive, and
+	// clamp it so it is non-increasing.
+	tau := -1.0
+	prev := inf
+	for m := 0; 2*m+1 < n; m++ {
+		pair := (ac.at(2*m) + ac.at(2*m+1)) / g0
+		if pair <= 0 {
+			break
+		}
+		pair = math.Min(pair, prev)
+		tau += 2 * pair
+		prev = pair
+	}
+	// For strongly negatively correlated series, τ may be near or
+	// below 0. Following Stan, limit the effective sample size to
+	// n·log10(n), or n for short series.
+	tau = math.Max(tau, 1/math.Max(1, math.Log10(float64(n))))
+	return float64(n) / tau
+}
+
+// BatchMeansSE returns the batch means estimate of the standard error
+// of the mean of the series xs. This divides xs into the given number
+// of contiguous batches of equal size and computes the standard
+// error from the variance of the batch means. Any values left over
+// from dividing xs into batches are ignored. If batches is 0, it uses
+// ⌊√len(xs)⌋.
+//
+// Batch means is consistent if the batches are much longer than the
+// autocorrelation time of the series, so that the batch means are
+// approximately independent. It has batches-1 degrees of freedom.
+//
+// If there are fewer than 2 batches or xs has fewer values than
+// batches, this returns NaN.
+func BatchMeansSE(xs []float64, batches int) float64 {
+	if batches == 0 {
+		batches = int(math.Sqrt(float64(len(xs))))
+	}
+	if batches < 2 || len(xs) < batches {
+		return nan
+	}
+	size := len(xs) / batches
+	means := make([]float64, batches)
+	for i := range means {
+		means[i] = Mean(xs[i*size : (i+1)*size])
+	}
+	return math.Sqrt(Variance(means) / float64(batches))
+}
+
+// NeweyWestSE returns the Newey-West heteroskedasticity and
+// autocorrelation consistent (HAC) estimate of the standard error of
+// the mean of the series xs. This estimates the long-run variance of
+// xs from its autocovariances up to the given lag, using Bartlett
+// weights 1 - k/(lag+1), which ensures the estimate is
+// non-negative. If lag is negative, it uses the automatic lag
+// ⌊4(n/100)^(2/9)⌋.
+//
+// With lag 0, this is the standard error assuming independent
+// values, but using the biased variance estimator.
+//
+// If xs is empty, this returns NaN.
+//
+// Newey, Whitney K.; West, Kenneth D. (1987). "A Simple, Positive
+// Semi-definite, Heteroskedasticity and Autocorrelation Consistent
+// Covariance Matrix". Econometrica 55 (3): 703–708.
+func NeweyWestSE(xs []float64, lag int) float64 {
+	n := len(xs)
+	if n == 0 {
+		return nan
+	}
+	if lag < 0 {
+		lag = int(4 * math.Pow(float64(n)/100, 2.0/9))
+	}
+	lag = minint(lag, n-1)
+	ac := newAutocov(xs)
+	lrv := ac.at(0)
+	for k := 1; k <= lag; k++ {
+		lrv += 2 * (1 - float64(k)/float64(lag+1)) * ac.at(k)
+	}
+	return math.Sqrt(math.Max(0, lrv) / float64(n))
+}
+
+// A MeanCIMethod is a method for computing the confidence interval
+// of the mean of a series.
+type MeanCIMethod int
+
+//go:generate stringer -type=MeanCIMethod
+
+const (
+	// MeanCIIID assumes the values are independent and uses the
+	// sample standard deviation, like MeanCI.
+	MeanCIIID MeanCIMethod = iota
+
+	// MeanCIEffectiveSize uses the sample standard deviation, but
+	// replaces the sample size with EffectiveSampleSize, both in
+	// the standard error and the degrees of freedom.
+	MeanCIEffectiveSize
+
+	// MeanCIBatchMeans uses BatchMeansSE with the default number
+	// of batches b and a t-distribution with b-1 degrees of
+	// freedom.
+	MeanCIBatchMeans
+
+	// MeanCINeweyWest uses NeweyWestSE with the automatic lag and
+	// a t-distribution with n-1 degrees of freedom.
+	MeanCINeweyWest
+)
+
+// MeanCIWith returns the arithmetic mean of the series xs and its
+// confidence interval, computed using method. Unlike MeanCI, all
+// methods other than MeanCIIID account for autocorrelation between
+// the values of xs.
+//
+// If xs is too small to compute the interval using method, this
+// returns an infinite interval. As with MeanCI, if xs has more than
+// one value and they are all equal, the interval is [mean, mean].
+func MeanCIWith(xs []float64, confidence float64, method MeanCIMethod) (mean, lo, hi float64) {
+	if method == MeanCIIID {
+		return MeanCI(xs, confidence)
+	}
+	mean = Mean(xs)
+	if confidence <= 0 {
+		return mean, mean, mean
+	}
+	if len(xs) > 1 && confidence < 1 && StdDev(xs) == 0 {
+		// The autocorrelation of a constant series is
+		// undefined, but there is no uncertainty in the mean.
+		return mean, mean, mean
+	}
+
+	var se, dof float64
+	n := float64(len(xs))
+	switch method {
+	case MeanCIEffectiveSize:
+		ess := EffectiveSampleSize(xs)
+		se, dof = StdDev(xs)/math.Sqrt(ess), ess-1
+	case MeanCIBatchMeans:
+		se = BatchMeansSE(xs, 0)
+		dof = math.Floor(math.Sqrt(n)) - 1
+	case MeanCINeweyWest:
+		se, dof = NeweyWestSE(xs, -1), n-1
+	default:
+		panic("unknown MeanCIMethod")
+	}
+	if confidence >= 1 || !(dof > 0) || math.IsNaN(se) {
+		return mean, -inf, inf
+	}
+	t := -InvCDF(TDist{V: dof})((1 - confidence) / 2)
+	return mean, mean - t*se, mean + t*se
+}
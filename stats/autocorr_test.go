@@ -0,0 +1,175 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package stats
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+)
+
+// ar1 returns n values of an AR(1) process with coefficient phi and
+// unit innovation variance, started from its stationary distribution.
+func ar1(r *rand.Rand, n int, phi float64) []float64 {
+	xs := make([]float64, n)
+	x := r.NormFloat64() / math.Sqrt(1-phi*phi)
+	for i := range xs {
+		xs[i] = x
+		x = phi*x + r.NormFloat64()
+	}
+	return xs
+}
+
+func TestAutocorrelation(t *testing.T) {
+	xs := []float64{1, 3, 2, 5, 4}
+	// Mean 3, deviations -2, 0, -1, 2, 1, sum of squares 10.
+	want := []float64{1, (0 + 0 - 2 + 2) / 10.0, (2 + 0 - 1) / 10.0, (-4 + 0) / 10.0, -2 / 10.0}
+	got := Autocorrelation(xs, 10)
+	if len(got) != len(want) {
+		t.Fatalf("Autocorrelation = %v, want %v", got, want)
+	}
+	for i := range want {
+		if !aeq(got[i], want[i]) {
+			t.Errorf("Autocorrelation = %v, want %v", got, want)
+			break
+		}
+	}
+	if got := Autocorrelation([]float64{2, 2, 2}, 1); got[0] != 1 || !math.IsNaN(got[1]) {
+		t.Errorf("Autocorrelation of constant series = %v, want [1 NaN]", got)
+	}
+
+	r := rand.New(rand.NewSource(1))
+	xs = ar1(r, 20000, 0.7)
+	acf := Autocorrelation(xs, 5)
+	pacf := PartialAutocorrelation(xs, 5)
+	for k := 1; k <= 5; k++ {
+		if want := math.Pow(0.7, float64(k)); math.Abs(acf[k]-want) > 0.03 {
+			t.Errorf("AR(1) acf[%d] = %v, want ~%v", k, acf[k], want)
+		}
+		want := 0.0
+		if k == 1 {
+			want = 0.7
+		}
+		if math.Abs(pacf[k]-want) > 0.03 {
+			t.Errorf("AR(1) pacf[%d] = %v, want ~%v", k, pacf[k], want)
+		}
+	}
+}
+
+func TestPACFFromACF(t *testing.T) {
+	// An AR(2) process x[i] = a x[i-1] + b x[i-2] + e has
+	// ρ1 = a/(1-b) and ρk = a ρ(k-1) + b ρ(k-2), and its partial
+	// autocorrelations are ρ1, b, 0, 0, ....
+	a, b := 0.5, 0.3
+	acf := []float64{1, a / (1 - b)}
+	for k := 2; k < 6; k++ {
+		acf = append(acf, a*acf[k-1]+b*acf[k-2])
+	}
+	want := []float64{1, acf[1], b, 0, 0, 0}
+	got := pacfFromACF(acf)
+	for i := range want {
+		if !aeqTol(got[i], want[i], 1e-12) {
+			t.Errorf("pacf = %v, want %v", got, want)
+			break
+		}
+	}
+}
+
+func TestEffectiveSampleSize(t *testing.T) {
+	r := rand.New(rand.NewSource(2))
+	for _, phi := range []float64{0, 0.5, 0.9} {
+		n := 20000
+		// τ = (1+φ)/(1-φ) for AR(1).
+		want := float64(n) * (1 - phi) / (1 + phi)
+		if got := EffectiveSampleSize(ar1(r, n, phi)); math.Abs(got/want-1) > 0.15 {
+			t.Errorf("φ=%v: ESS = %v, want ~%v", phi, got, want)
+		}
+	}
+	// An alternating series is limited to n log10(n).
+	alt := make([]float64, 100)
+	for i := range alt {
+		alt[i] = float64(i % 2)
+	}
+	if got := EffectiveSampleSize(alt); got != 200 {
+		t.Errorf("ESS of alternating series = %v, want 200", got)
+	}
+	if got := EffectiveSampleSize([]float64{1}); got != 1 {
+		t.Errorf("ESS of one value = %v, want 1", got)
+	}
+}
+
+func TestStandardErrors(t *testing.T) {
+	xs := []float64{1, 3, 2, 5, 4, 7}
+	// Batch means of pairs are 2, 3.5, 5.5.
+	if got, want := BatchMeansSE(xs, 3), math.Sqrt(Variance([]float64{2, 3.5, 5.5})/3); !aeq(got, want) {
+		t.Errorf("BatchMeansSE = %v, want %v", got, want)
+	}
+	if got := BatchMeansSE(xs, 1); !math.IsNaN(got) {
+		t.Errorf("BatchMeansSE with 1 batch = %v, want NaN", got)
+	}
+	n := float64(len(xs))
+	if got, want := NeweyWestSE(xs, 0), StdDev(xs)*math.Sqrt((n-1)/n)/math.Sqrt(n); !aeq(got, want) {
+		t.Errorf("NeweyWestSE(lag 0) = %v, want %v", got, want)
+	}
+
+	// For AR(1), the standard error of the mean is approximately
+	// sqrt(τ Var(x) / n).
+	r := rand.New(rand.NewSource(3))
+	const phi = 0.8
+	xs = ar1(r, 40000, phi)
+	want := math.Sqrt((1 + phi) / (1 - phi) / (1 - phi*phi) / float64(len(xs)))
+	if got := BatchMeansSE(xs, 0); math.Abs(got/want-1) > 0.25 {
+		t.Errorf("BatchMeansSE = %v, want ~%v", got, want)
+	}
+	if got := NeweyWestSE(xs, 100); math.Abs(got/want-1) > 0.1 {
+		t.Errorf("NeweyWestSE = %v, want ~%v", got, want)
+	}
+}
+
+func TestMeanCIWith(t *testing.T) {
+	xs := []float64{1, 3, 2, 5, 4, 7}
+	m, lo, hi := MeanCIWith(xs, 0.95, MeanCIIID)
+	wm, wlo, whi := MeanCI(xs, 0.95)
+	if m != wm || lo != wlo || hi != whi {
+		t.Errorf("MeanCIIID = %v [%v, %v], want %v [%v, %v]", m, lo, hi, wm, wlo, whi)
+	}
+	if _, lo, hi := MeanCIWith(xs[:3], 0.95, MeanCIBatchMeans); !math.IsInf(lo, -1) || !math.IsInf(hi, 1) {
+		t.Errorf("MeanCIBatchMeans with 1 batch = [%v, %v], want infinite", lo, hi)
+	}
+	// A constant series has a zero-width interval, like MeanCI.
+	for _, method := range []MeanCIMethod{MeanCIIID, MeanCIEffectiveSize, MeanCIBatchMeans, MeanCINeweyWest} {
+		if m, lo, hi := MeanCIWith([]float64{2, 2, 2, 2, 2}, 0.95, method); m != 2 || lo != 2 || hi != 2 {
+			t.Errorf("%v of constant series = %v [%v, %v], want 2 [2, 2]", method, m, lo, hi)
+		}
+	}
+
+	// Check the coverage of each method for AR(1) series.
+	r := rand.New(rand.NewSource(4))
+	const trials = 400
+	methods := []MeanCIMethod{MeanCIIID, MeanCIEffectiveSize, MeanCIBatchMeans, MeanCINeweyWest}
+	covered := make([]int, len(methods))
+	for i := 0; i < trials; i++ {
+		xs := ar1(r, 1000, 0.7)
+		for j, method := range methods {
+			if _, lo, hi := MeanCIWith(xs, 0.95, method); lo <= 0 && 0 <= hi {
+				covered[j]++
+			}
+		}
+	}
+	for j, method := range methods {
+		coverage := float64(covered[j]) / trials
+		if method == MeanCIIID {
+			if coverage > 0.75 {
+				t.Errorf("%v: coverage %v, want much less than 0.95", method, coverage)
+			}
+		} else if coverage < 0.85 {
+			// These estimators underestimate the standard
+			// error somewhat for strongly autocorrelated
+			// series of this length, so coverage is a little
+			// below nominal.
+			t.Errorf("%v: coverage %v, want ~0.95", method, coverage)
+		}
+	}
+}
@@ -0,0 +1,169 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package stats
+
+import (
+	"math"
+	"math/rand"
+)
+
+// This file implements Bayesian comparison of two groups (A/B
+// comparison) using conjugate priors. The posterior distributions
+// are ordinary Dist values, so they can be compared with
+// ProbGreater, ComparePosteriors, and CredibleInterval, or with any
+// other function that accepts a Dist.
+
+// BetaBinomialPosterior returns the posterior distribution of the
+// probability of success in a series of independent Bernoulli trials,
+// given the prior distribution of this probability and the number of
+// successes in some number of trials.
+//
+// Common choices of prior are the uniform prior BetaDist{1, 1} and
+// the Jeffreys prior BetaDist{0.5, 0.5}.
+func BetaBinomialPosterior(prior BetaDist, successes, trials int) BetaDist {
+	if successes < 0 || successes > trials {
+		panic("successes must be in [0, trials]")
+	}
+	return BetaDist{prior.Alpha + float64(successes), prior.Beta + float64(trials-successes)}
+}
+
+// NormalInvGamma is a normal-inverse-gamma distribution, which is the
+// conjugate prior of the mean μ and variance σ² of a normal
+// distribution when both are unknown. Under this distribution, σ² is
+// distributed as InvGamma(Alpha, Beta), and given σ², μ is distributed
+// as Normal(Mu, σ²/Kappa).
+//
+// Kappa and Alpha act as pseudo-counts of prior observations of the
+// mean and variance, respectively. The improper prior
+// NormalInvGamma{Alpha: -0.5} is a common noninformative choice; with
+// this prior, the credible intervals of the mean are the same as the
+// classical Student's t confidence intervals.
+type NormalInvGamma struct {
+	Mu, Kappa, Alpha, Beta float64
+}
+
+// Update returns the posterior distribution given prior distribution
+// d and a sample xs from a normal distribution.
+func (d NormalInvGamma) Update(xs []float64) NormalInvGamma {
+	if len(xs) == 0 {
+		return d
+	}
+	n := float64(len(xs))
+	mean := Mean(xs)
+	ss := 0.0
+	for _, x := range xs {
+		ss += (x - mean) * (x - mean)
+	}
+	kappa := d.Kappa + n
+	return NormalInvGamma{
+		Mu:    (d.Kappa*d.Mu + n*mean) / kappa,
+		Kappa: kappa,
+		Alpha: d.Alpha + n/2,
+		Beta:  d.Beta + ss/2 + d.Kappa*n*(mean-d.Mu)*(mean-d.Mu)/(2*kappa),
+	}
+}
+
+// MarginalMean returns the marginal distribution of the mean μ under
+// d. This is a Student's t-distribution with 2*Alpha degrees of
+// freedom, located at Mu, with scale sqrt(Beta/(Alpha*Kappa)).
+//
+// This panics if Kappa, Alpha, or Beta is not positive, which may
+// happen for improper priors that have not been updated with enough
+// data.
+func (d NormalInvGamma) MarginalMean() LocScaleDist {
+	if !(d.Kappa > 0 && d.Alpha > 0 && d.Beta > 0) {
+		panic("NormalInvGamma requires positive Kappa, Alpha, and Beta")
+	}
+	return LocScale(TDist{2 * d.Alpha}, d.Mu, math.Sqrt(d.Beta/(d.Alpha*d.Kappa)))
+}
+
+// ProbGreater returns Pr[B > A], where A and B are independent random
+// variables distributed according to a and b, respectively.
+//
+// This is computed by numerical integration, so it is deterministic
+// and usually accurate to about 1e-8.
+func ProbGreater(a, b Dist) float64 {
+	// Pr[B > A] = ∫ Pr[A < x] dF_B(x). Substituting x = F_B⁻¹(u)
+	// gives an integral over [0, 1] of a bounded, monotonic
+	// function, which avoids any poles in the PDF of b.
+	invB := InvCDF(b)
+	f := func(u float64) float64 {
+		return a.CDF(invB(u))
+	}
+	sum := 0.0
+	pts := append(append([]float64{0}, divergenceQuantiles...), 1)
+	for i := 1; i < len(pts); i++ {
+		sum += adaptiveSimpson(f, pts[i-1], pts[i], 1e-10)
+	}
+	return math.Max(0, math.Min(1, sum))
+}
+
+// CredibleInterval returns the equal-tailed credible interval of
+// posterior distribution d at the given credibility level (e.g.,
+// 0.95). That is, the interval between its (1-credibility)/2 and
+// (1+credibility)/2 quantiles.
+func CredibleInterval(d DistCommon, credibility float64) (lo, hi float64) {
+	inv := InvCDF(d)
+	return inv((1 - credibility) / 2), inv((1 + credibility) / 2)
+}
+
+// PosteriorComparison is the result of ComparePosteriors.
+type PosteriorComparison struct {
+	// ProbBGreater is the estimated Pr[B > A].
+	ProbBGreater float64
+
+	// LossA is the estimated expected loss of choosing A if
+	// larger values are better. That is, E[max(B - A, 0)].
+	// Likewise, LossB is E[max(A - B, 0)]. If smaller values are
+	// better, these are swapped.
+	LossA, LossB float64
+
+	// Diff is the sample of B - A drawn from the posteriors, in
+	// sorted order.
+	Diff Sample
+}
+
+// DiffCI returns the equal-tailed credible interval of B - A at the
+// given credibility level (e.g., 0.95), estimated from c.Diff.
+func (c *PosteriorComparison) DiffCI(credibility float64) (lo, hi float64) {
+	return c.Diff.Quantile((1 - credibility) / 2), c.Diff.Quantile((1 + credibility) / 2)
+}
+
+// ComparePosteriors compares the posterior distributions of two
+// groups A and B by Monte Carlo simulation, drawing the given number
+// of independent samples from a and b using source of randomness r.
+// If r is nil, it uses the default global source.
+//
+// The standard error of the estimates decreases with the square root
+// of samples. 100,000 samples is usually enough to estimate
+// probabilities to within about 0.003.
+func ComparePosteriors(a, b DistCommon, samples int, r *rand.Rand) *PosteriorComparison {
+	if samples <= 0 {
+		panic("samples must be positive")
+	}
+	randA, randB := Rand(a), Rand(b)
+	diff := make([]float64, samples)
+	var greater int
+	var lossA, lossB float64
+	for i := range diff {
+		d := randB(r) - randA(r)
+		diff[i] = d
+		if d > 0 {
+			greater++
+			lossA += d
+		} else {
+			lossB -= d
+		}
+	}
+	n := float64(samples)
+	c := &PosteriorComparison{
+		ProbBGreater: float64(greater) / n,
+		LossA:        lossA / n,
+		LossB:        lossB / n,
+		Diff:         Sample{Xs: diff},
+	}
+	c.Diff.Sort()
+	return c
+}
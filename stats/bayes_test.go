@@ -0,0 +1,115 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package stats
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+)
+
+func TestBetaBinomialPosterior(t *testing.T) {
+	got := BetaBinomialPosterior(BetaDist{1, 1}, 3, 10)
+	if want := (BetaDist{4, 8}); got != want {
+		t.Errorf("posterior = %+v, want %+v", got, want)
+	}
+	// The Jeffreys posterior's credible interval is the
+	// Jeffreys proportion interval for 0 < successes < trials.
+	post := BetaBinomialPosterior(BetaDist{0.5, 0.5}, 682, 925)
+	lo, hi := CredibleInterval(post, 0.95)
+	wlo, whi := ProportionCI(682, 925, 0.95, ProportionJeffreys)
+	if !aeq(lo, wlo) || !aeq(hi, whi) {
+		t.Errorf("credible interval = [%v, %v], want [%v, %v]", lo, hi, wlo, whi)
+	}
+}
+
+func TestNormalInvGamma(t *testing.T) {
+	xs := []float64{5.1, 4.9, 6.2, 5.8, 6.0, 5.5, 5.3, 6.1}
+	post := NormalInvGamma{Alpha: -0.5}.Update(xs)
+	// With this prior, the credible interval of the mean is the
+	// classical t confidence interval.
+	lo, hi := CredibleInterval(post.MarginalMean(), 0.95)
+	_, wlo, whi := MeanCI(xs, 0.95)
+	if !aeqTol(lo, wlo, 1e-8) || !aeqTol(hi, whi, 1e-8) {
+		t.Errorf("credible interval = [%v, %v], want [%v, %v]", lo, hi, wlo, whi)
+	}
+	if got := DistMean(post.MarginalMean()); !aeq(got, Mean(xs)) {
+		t.Errorf("posterior mean = %v, want %v", got, Mean(xs))
+	}
+
+	// Updating in two steps is the same as updating at once.
+	prior := NormalInvGamma{Mu: 4, Kappa: 2, Alpha: 3, Beta: 1.5}
+	once := prior.Update(xs)
+	twice := prior.Update(xs[:3]).Update(xs[3:])
+	if !aeq(once.Mu, twice.Mu) || !aeq(once.Kappa, twice.Kappa) ||
+		!aeq(once.Alpha, twice.Alpha) || !aeq(once.Beta, twice.Beta) {
+		t.Errorf("one update %+v != two updates %+v", once, twice)
+	}
+}
+
+func TestProbGreater(t *testing.T) {
+	a, b := NormalDist{1, 2}, NormalDist{2, 1.5}
+	want := StdNormal.CDF(1 / 2.5)
+	if got := ProbGreater(a, b); !aeqTol(got, want, 1e-8) {
+		t.Errorf("ProbGreater(%v, %v) = %v, want %v", a, b, got, want)
+	}
+
+	// For Beta distributions with integer B.Alpha, there is a
+	// closed form. See Miller, Evan. "Formulas for Bayesian A/B
+	// Testing".
+	lbeta := func(x, y float64) float64 { return lgamma(x) + lgamma(y) - lgamma(x+y) }
+	for _, c := range [][2]BetaDist{
+		{{1, 1}, {1, 1}},
+		{{13, 90}, {21, 85}},
+		{{0.5, 10.5}, {3, 8.5}},
+		{{200, 800}, {230, 770}},
+	} {
+		a, b := c[0], c[1]
+		want := 0.0
+		for i := 0.0; i < b.Alpha; i++ {
+			want += math.Exp(lbeta(a.Alpha+i, a.Beta+b.Beta) - math.Log(b.Beta+i) -
+				lbeta(1+i, b.Beta) - lbeta(a.Alpha, a.Beta))
+		}
+		if got := ProbGreater(a, b); !aeqTol(got, want, 1e-7) {
+			t.Errorf("ProbGreater(%+v, %+v) = %v, want %v", a, b, got, want)
+		}
+	}
+}
+
+func TestComparePosteriors(t *testing.T) {
+	a, b := NormalDist{1, 2}, NormalDist{2, 1.5}
+	r := rand.New(rand.NewSource(1))
+	c := ComparePosteriors(a, b, 100000, r)
+
+	// B - A is distributed Normal(1, 2.5).
+	diff := NormalDist{1, 2.5}
+	if want := 1 - diff.CDF(0); math.Abs(c.ProbBGreater-want) > 0.005 {
+		t.Errorf("ProbBGreater = %v, want ~%v", c.ProbBGreater, want)
+	}
+	// E[max(D, 0)] = μΦ(μ/σ) + σφ(μ/σ), and E[max(-D, 0)] is the
+	// same with -μ.
+	loss := func(μ, σ float64) float64 {
+		return μ*StdNormal.CDF(μ/σ) + σ*StdNormal.PDF(μ/σ)
+	}
+	if want := loss(1, 2.5); math.Abs(c.LossA-want) > 0.02 {
+		t.Errorf("LossA = %v, want ~%v", c.LossA, want)
+	}
+	if want := loss(-1, 2.5); math.Abs(c.LossB-want) > 0.02 {
+		t.Errorf("LossB = %v, want ~%v", c.LossB, want)
+	}
+	lo, hi := c.DiffCI(0.9)
+	if wlo, whi := CredibleInterval(diff, 0.9); math.Abs(lo-wlo) > 0.05 || math.Abs(hi-whi) > 0.05 {
+		t.Errorf("DiffCI = [%v, %v], want ~[%v, %v]", lo, hi, wlo, whi)
+	}
+
+	// Monte Carlo should agree with numerical integration for
+	// Beta posteriors.
+	pa := BetaBinomialPosterior(BetaDist{1, 1}, 12, 100)
+	pb := BetaBinomialPosterior(BetaDist{1, 1}, 20, 100)
+	c = ComparePosteriors(pa, pb, 100000, r)
+	if want := ProbGreater(pa, pb); math.Abs(c.ProbBGreater-want) > 0.005 {
+		t.Errorf("ProbBGreater = %v, want ~%v", c.ProbBGreater, want)
+	}
+}
@@ -0,0 +1,110 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package stats
+
+import (
+	"math"
+	"math/rand"
+
+	"github.com/aclements/go-moremath/mathx"
+)
+
+// BetaDist is a beta distribution with shape parameters Alpha and
+// Beta, which must both be positive. This is a continuous
+// distribution over [0, 1].
+type BetaDist struct {
+	Alpha, Beta float64
+}
+
+func (d BetaDist) PDF(x float64) float64 {
+	if x < 0 || x > 1 {
+		return 0
+	} else if x == 1 {
+		// Reflect to use betaPDF's handling of 0.
+		return betaPDF(0, math.Inf(-1), 0, d.Beta, d.Alpha)
+	}
+	return betaPDF(x, math.Log(x), math.Log1p(-x), d.Alpha, d.Beta)
+}
+
+func (d BetaDist) CDF(x float64) float64 {
+	if math.IsNaN(x) {
+		return nan
+	} else if x <= 0 {
+		return 0
+	} else if x >= 1 {
+		return 1
+	}
+	return mathx.BetaInc(x, d.Alpha, d.Beta)
+}
+
+func (d BetaDist) InvCDF(y float64) float64 {
+	if y < 0 || y > 1 || math.IsNaN(y) {
+		return nan
+	} else if y == 0 {
+		return 0
+	} else if y == 1 {
+		return 1
+	}
+	// BetaInc is accurate to about 1e-14, so bisect to the
+	// precision of x rather than iterating to a fixed point with
+	// Newton's method, which may not converge.
+	_, x := bisectBool(func(x float64) bool {
+		return d.CDF(x) < y
+	}, 0, 1, 0)
+	return x
+}
+
+// Bounds returns the support of d, [0, 1].
+func (d BetaDist) Bounds() (float64, float64) {
+	return 0, 1
+}
+
+func (d BetaDist) Mean() float64 {
+	return d.Alpha / (d.Alpha + d.Beta)
+}
+
+func (d BetaDist) Variance() float64 {
+	s := d.Alpha + d.Beta
+	return d.Alpha * d.Beta / (s * s * (s + 1))
+}
+
+func (d BetaDist) Rand(r *rand.Rand) float64 {
+	x := randGamma(r, d.Alpha)
+	y := randGamma(r, d.Beta)
+	return x / (x + y)
+}
+
+// randGamma returns a random number from the gamma distribution with
+// the given shape parameter and scale 1. If r is nil, it uses the
+// default global source.
+func randGamma(r *rand.Rand, shape float64) float64 {
+	uniform, normal := rand.Float64, rand.NormFloat64
+	if r != nil {
+		uniform, normal = r.Float64, r.NormFloat64
+	}
+	if shape < 1 {
+		// Use Gamma(shape) = Gamma(shape+1) * U^(1/shape).
+		u := uniform()
+		return randGamma(r, shape+1) * math.Pow(u, 1/shape)
+	}
+
+	// Marsaglia, George; Tsang, Wai Wan (2000). "A simple method
+	// for generating gamma variables". ACM Transactions on
+	// Mathematical Software 26 (3): 363–372.
+	d := shape - 1.0/3
+	c := 1 / math.Sqrt(9*d)
+	for {
+		x := normal()
+		v := 1 + c*x
+		if v <= 0 {
+			continue
+		}
+		v = v * v * v
+		u := uniform()
+		if u < 1-0.0331*x*x*x*x || math.Log(u) < x*x/2+d*(1-v+math.Log(v)) {
+			return d * v
+		}
+	}
+}
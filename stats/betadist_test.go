@@ -0,0 +1,71 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package stats
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+	"testing"
+)
+
+func TestBetaDist(t *testing.T) {
+	// Beta(1, 1) is uniform and Beta(2, 1) has PDF 2x.
+	testFunc(t, "BetaDist{1, 1}.PDF", BetaDist{1, 1}.PDF, map[float64]float64{
+		-0.1: 0, 0: 1, 0.3: 1, 1: 1, 1.1: 0,
+	})
+	testFunc(t, "BetaDist{2, 1}.PDF", BetaDist{2, 1}.PDF, map[float64]float64{
+		0: 0, 0.25: 0.5, 1: 2,
+	})
+	testFunc(t, "BetaDist{2, 1}.CDF", BetaDist{2, 1}.CDF, map[float64]float64{
+		-1: 0, 0: 0, 0.5: 0.25, 1: 1, 2: 1,
+	})
+	if got := (BetaDist{0.5, 0.5}).PDF(1); !math.IsInf(got, 1) {
+		t.Errorf("BetaDist{0.5, 0.5}.PDF(1) = %v, want +Inf", got)
+	}
+
+	for _, d := range []BetaDist{{1, 1}, {2, 5}, {0.5, 0.5}, {30, 3}} {
+		testInvCDF(t, d, true)
+		testPDFIntegral(t, fmt.Sprintf("%+v", d), d, []float64{0.01, 0.2, 0.5, 0.8, 0.99})
+		if d.Alpha < 1 || d.Beta < 1 {
+			// The PDF has poles, which defeat numerical
+			// integration.
+			continue
+		}
+		if got := DistVariance(struct{ Dist }{d}); !aeqTol(got, d.Variance(), 1e-6) {
+			t.Errorf("%+v: numerical variance %v, want %v", d, got, d.Variance())
+		}
+	}
+	// Quantiles in the far tail must be accurate.
+	d := BetaDist{1, 1000}
+	if got, want := d.InvCDF(0.025), -math.Expm1(math.Log1p(-0.025)/1000); !aeqTol(got, want, 1e-12) {
+		t.Errorf("%+v.InvCDF(0.025) = %v, want %v", d, got, want)
+	}
+}
+
+func TestBetaDistRand(t *testing.T) {
+	for _, d := range []BetaDist{{1, 1}, {2, 5}, {0.5, 0.5}, {30, 3}} {
+		testRandQuantiles(t, fmt.Sprintf("%+v", d), d, d.Rand)
+	}
+}
+
+// testRandQuantiles checks that the quantiles of values drawn from
+// rnd match the quantiles of dist.
+func testRandQuantiles(t *testing.T, name string, dist DistCommon, rnd func(*rand.Rand) float64) {
+	t.Helper()
+	r := rand.New(rand.NewSource(1))
+	s := Sample{Xs: make([]float64, 20000)}
+	for i := range s.Xs {
+		s.Xs[i] = rnd(r)
+	}
+	s.Sort()
+	for _, q := range []float64{0.1, 0.25, 0.5, 0.75, 0.9} {
+		// Compare in probability space, where the standard
+		// error is sqrt(q(1-q)/n) <= 0.0036.
+		if got := dist.CDF(s.Quantile(q)); math.Abs(got-q) > 0.015 {
+			t.Errorf("%s: CDF of sample quantile %v = %v", name, q, got)
+		}
+	}
+}
@@ -0,0 +1,226 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package stats
+
+import "math"
+
+// A BinomialTestResult is the result of a binomial test.
+type BinomialTestResult struct {
+	// Successes and Trials are the number of successes and the
+	// number of trials.
+	Successes, Trials int
+
+	// P0 is the probability of success under the null
+	// hypothesis.
+	P0 float64
+
+	// AltHypothesis specifies the alternative hypothesis tested
+	// by this test against the null hypothesis that the
+	// probability of success is P0. LocationLess means the
+	// probability of success is less than P0.
+	AltHypothesis LocationHypothesis
+
+	// P is the p-value of this test for the given null
+	// hypothesis.
+	P float64
+}
+
+// Proportion returns the observed proportion of successes.
+func (r *BinomialTestResult) Proportion() float64 {
+	return float64(r.Successes) / float64(r.Trials)
+}
+
+// CI returns the confidence interval of the probability of success
+// at the given confidence level (e.g., 0.95) using the given method.
+func (r *BinomialTestResult) CI(confidence float64, method ProportionCIMethod) (lo, hi float64) {
+	return ProportionCI(r.Successes, r.Trials, confidence, method)
+}
+
+// BinomialTest performs an exact binomial test of the null hypothesis
+// that the probability of success in each of a series of independent
+// Bernoulli trials is p0, given the number of successes in some
+// number of trials.
+//
+// For the two-sided test, the p-value is the total probability of
+// all outcomes that are no more likely than the observed outcome.
+// This is the same as R's binom.test.
+//
+// This can fail with ErrSampleSize if trials is 0. It panics if
+// successes is not in [0, trials] or p0 is not in [0, 1].
+func BinomialTest(successes, trials int, p0 float64, alt LocationHypothesis) (*BinomialTestResult, error) {
+	if successes < 0 || successes > trials {
+		panic("successes must be in [0, trials]")
+	}
+	if !(0 <= p0 && p0 <= 1) {
+		panic("p0 must be in [0, 1]")
+	}
+	if trials == 0 {
+		return nil, ErrSampleSize
+	}
+
+	d := BinomialDist{N: trials, P: p0}
+	k := float64(successes)
+	var p float64
+	switch alt {
+	case LocationLess:
+		p = d.CDF(k)
+	case LocationGreater:
+		p = 1 - d.CDF(k-1)
+	case LocationDiffers:
+		// Allow for round-off error when comparing the
+		// probabilities of outcomes, as R does.
+		const relErr = 1 + 1e-7
+		pk := d.PMF(k) * relErr
+		mean := d.Mean()
+		if k == mean {
+			p = 1
+		} else if k < mean {
+			// Find the outcomes above the mean that are no
+			// more likely than k. The PMF decreases above
+			// the mode, so these are a suffix.
+			y := 0
+			for i := trials; float64(i) > mean && d.PMF(float64(i)) <= pk; i-- {
+				y++
+			}
+			p = d.CDF(k) + (1 - d.CDF(float64(trials-y)))
+		} else {
+			// Likewise for the outcomes below the mean.
+			y := 0
+			for i := 0; float64(i) < mean && d.PMF(float64(i)) <= pk; i++ {
+				y++
+			}
+			p = d.CDF(float64(y-1)) + (1 - d.CDF(k-1))
+		}
+		p = math.Min(1, p)
+	}
+	return &BinomialTestResult{Successes: successes, Trials: trials,
+		P0: p0, AltHypothesis: alt, P: p}, nil
+}
+
+// A ProportionCIMethod is a method for computing the confidence
+// interval of a binomial proportion.
+type ProportionCIMethod int
+
+//go:generate stringer -type=ProportionCIMethod
+
+const (
+	// ProportionClopperPearson is the "exact" Clopper-Pearson
+	// interval, which is obtained by inverting two one-sided
+	// binomial tests. Its coverage is always at least the
+	// requested confidence level, but it is often quite
+	// conservative.
+	//
+	// Clopper, C. J.; Pearson, E. S. (1934). "The use of
+	// confidence or fiducial limits illustrated in the case of
+	// the binomial". Biometrika 26 (4): 404–413.
+	ProportionClopperPearson ProportionCIMethod = iota
+
+	// ProportionWilson is the Wilson score interval, which is
+	// obtained by inverting the normal approximation to the
+	// binomial score test. Its average coverage is close to the
+	// requested confidence level, even for small samples.
+	//
+	// Wilson, E. B. (1927). "Probable inference, the law of
+	// succession, and statistical inference". Journal of the
+	// American Statistical Association 22 (158): 209–212.
+	ProportionWilson
+
+	// ProportionJeffreys is the equal-tailed Bayesian credible
+	// interval under the Jeffreys prior Beta(1/2, 1/2), with the
+	// lower bound set to 0 if there are no successes and the
+	// upper bound set to 1 if there are no failures. It has good
+	// coverage properties similar to the Wilson interval.
+	//
+	// Brown, L. D.; Cai, T. T.; DasGupta, A. (2001). "Interval
+	// Estimation for a Binomial Proportion". Statistical Science
+	// 16 (2): 101–133.
+	ProportionJeffreys
+)
+
+// ProportionCI returns the confidence interval of the probability of
+// success given the number of successes in some number of
+// independent Bernoulli trials, at the given confidence level (e.g.,
+// 0.95), computed using method.
+//
+// If trials is 0, this returns [0, 1].
+func ProportionCI(successes, trials int, confidence float64, method ProportionCIMethod) (lo, hi float64) {
+	if successes < 0 || successes > trials {
+		panic("successes must be in [0, trials]")
+	}
+	if trials == 0 {
+		return 0, 1
+	}
+	alpha := 1 - confidence
+	k, n := float64(successes), float64(trials)
+
+	switch method {
+	case ProportionClopperPearson:
+		lo, hi = 0, 1
+		if successes > 0 {
+			lo = BetaDist{k, n - k + 1}.InvCDF(alpha / 2)
+		}
+		if successes < trials {
+			hi = BetaDist{k + 1, n - k}.InvCDF(1 - alpha/2)
+		}
+
+	case ProportionWilson:
+		z := -StdNormal.InvCDF(alpha / 2)
+		z2 := z * z
+		center := (k + z2/2) / (n + z2)
+		half := z / (n + z2) * math.Sqrt(k*(n-k)/n+z2/4)
+		lo, hi = math.Max(0, center-half), math.Min(1, center+half)
+
+	case ProportionJeffreys:
+		post := BetaDist{k + 0.5, n - k + 0.5}
+		lo, hi = 0, 1
+		if successes > 0 {
+			lo = post.InvCDF(alpha / 2)
+		}
+		if successes < trials {
+			hi = post.InvCDF(1 - alpha/2)
+		}
+
+	default:
+		panic("unknown ProportionCIMethod")
+	}
+	return lo, hi
+}
+
+// SignTest performs a sign test of the null hypothesis that the
+// median of the differences between paired samples x1 and x2 is 0.
+// That is, that x1[i] is equally likely to be greater or less than
+// x2[i]. Unlike PairedTTest, this makes no assumptions about the
+// distribution of the differences.
+//
+// Pairs where x1[i] == x2[i] are discarded. The result is the
+// BinomialTest of the number of pairs where x1[i] > x2[i] (the
+// successes) among the remaining pairs (the trials) with p0 = 0.5.
+// Hence, LocationGreater tests the alternative hypothesis that x1
+// tends to be greater than x2.
+//
+// This can fail with ErrMismatchedSamples if x1 and x2 have different
+// lengths, ErrSampleSize if they are empty, or ErrSamplesEqual if
+// every pair is equal.
+func SignTest(x1, x2 []float64, alt LocationHypothesis) (*BinomialTestResult, error) {
+	if len(x1) != len(x2) {
+		return nil, ErrMismatchedSamples
+	}
+	if len(x1) == 0 {
+		return nil, ErrSampleSize
+	}
+	var pos, n int
+	for i := range x1 {
+		if x1[i] > x2[i] {
+			pos++
+			n++
+		} else if x1[i] < x2[i] {
+			n++
+		}
+	}
+	if n == 0 {
+		return nil, ErrSamplesEqual
+	}
+	return BinomialTest(pos, n, 0.5, alt)
+}
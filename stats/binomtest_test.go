@@ -0,0 +1,116 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package stats
+
+import (
+	"math"
+	"testing"
+)
+
+func TestBinomialTest(t *testing.T) {
+	check := func(k, n int, p0 float64, alt LocationHypothesis, want float64) {
+		t.Helper()
+		r, err := BinomialTest(k, n, p0, alt)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !aeqTol(r.P, want, 1e-6) {
+			t.Errorf("BinomialTest(%d, %d, %v, %v).P = %v, want %v", k, n, p0, alt, r.P, want)
+		}
+	}
+	// Symmetric null distribution.
+	d := BinomialDist{N: 20, P: 0.5}
+	check(7, 20, 0.5, LocationDiffers, 2*d.CDF(7))
+	check(7, 20, 0.5, LocationLess, d.CDF(7))
+	check(7, 20, 0.5, LocationGreater, 1-d.CDF(6))
+	check(10, 20, 0.5, LocationDiffers, 1)
+	// Asymmetric null distribution. From the example in R's
+	// binom.test documentation.
+	check(682, 925, 0.75, LocationDiffers, 0.3824916)
+	// For p0=0.2 and n=10, PMF(0) = 0.107 and PMF(4) = 0.088, so
+	// the outcomes at least as unlikely as 4 are 4 through 10,
+	// and those at least as unlikely as 0 also include 0.
+	d = BinomialDist{N: 10, P: 0.2}
+	check(4, 10, 0.2, LocationDiffers, 1-d.CDF(3))
+	check(0, 10, 0.2, LocationDiffers, d.PMF(0)+1-d.CDF(3))
+
+	if _, err := BinomialTest(0, 0, 0.5, LocationDiffers); err != ErrSampleSize {
+		t.Errorf("want ErrSampleSize, got %v", err)
+	}
+}
+
+func TestProportionCI(t *testing.T) {
+	// From the example in R's binom.test documentation.
+	lo, hi := ProportionCI(682, 925, 0.95, ProportionClopperPearson)
+	if !aeqTol(lo, 0.7076683, 1e-6) || !aeqTol(hi, 0.7654066, 1e-6) {
+		t.Errorf("Clopper-Pearson CI = [%v, %v], want [0.7076683, 0.7654066]", lo, hi)
+	}
+
+	const alpha = 0.05
+	z := -StdNormal.InvCDF(alpha / 2)
+	for _, c := range [][2]int{{0, 10}, {1, 10}, {5, 10}, {10, 10}, {3, 1000}} {
+		k, n := c[0], c[1]
+		kf, nf := float64(k), float64(n)
+
+		// Each Clopper-Pearson bound is where the
+		// corresponding one-sided test has p-value α/2.
+		lo, hi := ProportionCI(k, n, 1-alpha, ProportionClopperPearson)
+		if k == 0 && lo != 0 || k > 0 && !aeqTol(1-(BinomialDist{n, lo}).CDF(kf-1), alpha/2, 1e-9) {
+			t.Errorf("Clopper-Pearson CI for %d/%d has bad lower bound %v", k, n, lo)
+		}
+		if k == n && hi != 1 || k < n && !aeqTol((BinomialDist{n, hi}).CDF(kf), alpha/2, 1e-9) {
+			t.Errorf("Clopper-Pearson CI for %d/%d has bad upper bound %v", k, n, hi)
+		}
+
+		// Each Wilson bound is where the score statistic is
+		// ±z.
+		lo, hi = ProportionCI(k, n, 1-alpha, ProportionWilson)
+		for _, p := range []float64{lo, hi} {
+			if p == 0 && k == 0 || p == 1 && k == n {
+				continue
+			}
+			if score := math.Abs(kf/nf-p) / math.Sqrt(p*(1-p)/nf); !aeqTol(score, z, 1e-9) {
+				t.Errorf("Wilson CI for %d/%d has bound %v with score %v, want %v", k, n, p, score, z)
+			}
+		}
+
+		// The Jeffreys interval is the equal-tailed interval
+		// of the Beta(k+1/2, n-k+1/2) posterior.
+		lo, hi = ProportionCI(k, n, 1-alpha, ProportionJeffreys)
+		post := BetaDist{kf + 0.5, nf - kf + 0.5}
+		if k == 0 && lo != 0 || k > 0 && !aeqTol(post.CDF(lo), alpha/2, 1e-9) {
+			t.Errorf("Jeffreys CI for %d/%d has bad lower bound %v", k, n, lo)
+		}
+		if k == n && hi != 1 || k < n && !aeqTol(post.CDF(hi), 1-alpha/2, 1e-9) {
+			t.Errorf("Jeffreys CI for %d/%d has bad upper bound %v", k, n, hi)
+		}
+	}
+
+	r, _ := BinomialTest(682, 925, 0.75, LocationDiffers)
+	if lo, hi := r.CI(0.95, ProportionClopperPearson); !aeqTol(lo, 0.7076683, 1e-6) || !aeqTol(hi, 0.7654066, 1e-6) {
+		t.Errorf("CI = [%v, %v], want [0.7076683, 0.7654066]", lo, hi)
+	}
+}
+
+func TestSignTest(t *testing.T) {
+	x1 := []float64{1, 2, 3, 4, 5, 6, 7, 8, 9, 10}
+	x2 := []float64{0, 1, 2, 5, 4, 3, 7, 6, 8, 9}
+	// 8 positive differences, 1 negative, 1 zero.
+	r, err := SignTest(x1, x2, LocationGreater)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := 1 - (BinomialDist{9, 0.5}).CDF(7)
+	if r.Successes != 8 || r.Trials != 9 || !aeq(r.P, want) {
+		t.Errorf("want 8/9 with P=%v, got %+v", want, r)
+	}
+
+	if _, err := SignTest(x1, x2[1:], LocationDiffers); err != ErrMismatchedSamples {
+		t.Errorf("want ErrMismatchedSamples, got %v", err)
+	}
+	if _, err := SignTest(x1, x1, LocationDiffers); err != ErrSamplesEqual {
+		t.Errorf("want ErrSamplesEqual, got %v", err)
+	}
+}
@@ -0,0 +1,468 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package stats
+
+import (
+	"math"
+	"math/rand"
+	"sort"
+)
+
+// A ChangePointDetector finds the points in a time-ordered series at
+// which its distribution changes, such as commits at which a
+// benchmark's performance changed.
+//
+// All fields are optional and have reasonable defaults.
+type ChangePointDetector struct {
+	// Method is the change-point detection algorithm.
+	Method ChangePointMethod
+
+	// Cost is the segment cost function used by ChangePointPELT
+	// and ChangePointBinSeg.
+	Cost ChangePointCost
+
+	// Penalty is the increase in total cost required to add a
+	// change point, for ChangePointPELT and ChangePointBinSeg.
+	// Larger penalties find fewer change points. If this is 0, it
+	// uses the Bayesian information criterion: 2 log n for
+	// CostMean and 3 log n for CostMeanVar.
+	Penalty float64
+
+	// MinSize is the minimum number of values in each segment. If
+	// this is less than 2, it uses 2.
+	MinSize int
+
+	// Alpha is the significance level of the permutation test
+	// used to accept each change point by ChangePointEDivisive.
+	// If this is 0, it uses 0.05.
+	Alpha float64
+
+	// Permutations is the number of permutations used to test
+	// each change point by ChangePointEDivisive. If this is 0, it
+	// uses 199.
+	Permutations int
+
+	// Rand is the source of randomness for ChangePointEDivisive.
+	// If this is nil, it uses the default global source.
+	Rand *rand.Rand
+}
+
+// A ChangePointMethod is an algorithm for detecting change points.
+type ChangePointMethod int
+
+//go:generate stringer -type=ChangePointMethod
+
+const (
+	// ChangePointPELT finds the set of change points that
+	// minimizes the total cost of the segments plus Penalty for
+	// each change point. It uses the pruned exact linear time
+	// (PELT) algorithm, which takes O(n) time if the number of
+	// change points grows with n and O(n²) time in the worst
+	// case.
+	//
+	// Killick, R.; Fearnhead, P.; Eckley, I. A. (2012). "Optimal
+	// detection of changepoints with a linear computational
+	// cost". Journal of the American Statistical Association
+	// 107 (500): 1590–1598.
+	ChangePointPELT ChangePointMethod = iota
+
+	// ChangePointBinSeg uses binary segmentation, which
+	// repeatedly splits the segment whose best split reduces the
+	// total cost the most, for as long as this reduction exceeds
+	// Penalty. This is an approximation to ChangePointPELT that
+	// takes O(n log n) time for evenly spaced change points, but
+	// may miss short segments.
+	ChangePointBinSeg
+
+	// ChangePointEDivisive uses the nonparametric E-divisive
+	// method, which divides the series using the energy distance
+	// between segments. It detects any change in distribution,
+	// not just changes in mean or variance, and accepts each
+	// change point using a permutation test at significance
+	// level Alpha. It ignores Cost and Penalty.
+	//
+	// Matteson, D. S.; James, N. A. (2014). "A Nonparametric
+	// Approach for Multiple Change Point Analysis of Multivariate
+	// Data". Journal of the American Statistical Association
+	// 109 (505): 334–345.
+	ChangePointEDivisive
+)
+
+// A ChangePointCost is a cost function for segments of a series. Each
+// is twice the negative log likelihood of a segment under a normal
+// model, up to a constant.
+type ChangePointCost int
+
+//go:generate stringer -type=ChangePointCost
+
+const (
+	// CostMean detects changes in mean, assuming the variance is
+	// constant. The variance is estimated robustly from the
+	// differences between consecutive values, so it is not
+	// inflated by the changes in mean.
+	CostMean ChangePointCost = iota
+
+	// CostMeanVar detects changes in mean, variance, or both.
+	CostMeanVar
+)
+
+// A ChangePointResult is the result of change-point detection.
+type ChangePointResult struct {
+	// ChangePoints is the indexes of the series at which each new
+	// segment begins, in increasing order.
+	ChangePoints []int
+
+	// Segments are the values of the series between each change
+	// point. There are len(ChangePoints)+1 segments. These share
+	// storage with the series.
+	Segments []Sample
+}
+
+// Detect returns the change points of the series xs.
+func (d *ChangePointDetector) Detect(xs []float64) *ChangePointResult {
+	minSize := maxint(d.MinSize, 2)
+
+	var cps []int
+	if len(xs) >= 2*minSize {
+		switch d.Method {
+		case ChangePointPELT:
+			cps = pelt(newSegCost(xs, d.Cost), d.penalty(len(xs)), minSize)
+		case ChangePointBinSeg:
+			cps = binSeg(newSegCost(xs, d.Cost), d.penalty(len(xs)), minSize)
+		case ChangePointEDivisive:
+			alpha, perms := d.Alpha, d.Permutations
+			if alpha == 0 {
+				alpha = 0.05
+			}
+			if perms == 0 {
+				perms = 199
+			}
+			cps = eDivisive(xs, minSize, alpha, perms, d.Rand)
+		default:
+			panic("unknown ChangePointMethod")
+		}
+	}
+
+	res := &ChangePointResult{ChangePoints: cps}
+	start := 0
+	for _, cp := range append(cps, len(xs)) {
+		res.Segments = append(res.Segments, Sample{Xs: xs[start:cp]})
+		start = cp
+	}
+	return res
+}
+
+func (d *ChangePointDetector) penalty(n int) float64 {
+	if d.Penalty != 0 {
+		return d.Penalty
+	}
+	switch d.Cost {
+	case CostMean:
+		return 2 * math.Log(float64(n))
+	case CostMeanVar:
+		return 3 * math.Log(float64(n))
+	}
+	panic("unknown ChangePointCost")
+}
+
+// segCost computes the cost of segments of a series in O(1) time.
+type segCost struct {
+	kind ChangePointCost
+
+	// sum and sumSq are the prefix sums of the series and its
+	// squares, after subtracting the series mean to reduce
+	// round-off error.
+	sum, sumSq []float64
+
+	// sigma2 is the variance for CostMean. varFloor is the
+	// minimum variance of a segment for CostMeanVar, which keeps
+	// segments of constant values from having -Inf cost.
+	sigma2, varFloor float64
+}
+
+func newSegCost(xs []float64, kind ChangePointCost) *segCost {
+	c := &segCost{kind: kind}
+	mean := Mean(xs)
+	c.sum = make([]float64, len(xs)+1)
+	c.sumSq = make([]float64, len(xs)+1)
+	for i, x := range xs {
+		x -= mean
+		c.sum[i+1] = c.sum[i] + x
+		c.sumSq[i+1] = c.sumSq[i] + x*x
+	}
+
+	variance := Variance(xs)
+	switch kind {
+	case CostMean:
+		// If x[i] has standard deviation σ, then
+		// x[i+1] - x[i] has standard deviation √2σ.
+		diffs := make([]float64, len(xs)-1)
+		for i := range diffs {
+			diffs[i] = xs[i+1] - xs[i]
+		}
+		c.sigma2 = math.Pow(Sample{Xs: diffs}.MAD(MADNormal)/math.Sqrt2, 2)
+		if !(c.sigma2 > 0) {
+			c.sigma2 = variance
+		}
+		if !(c.sigma2 > 0) {
+			c.sigma2 = 1
+		}
+	case CostMeanVar:
+		c.varFloor = 1e-10 * variance
+		if !(c.varFloor > 0) {
+			c.varFloor = 1e-10
+		}
+	default:
+		panic("unknown ChangePointCost")
+	}
+	return c
+}
+
+// cost returns the cost of segment [a, b) of the series.
+func (c *segCost) cost(a, b int) float64 {
+	n := float64(b - a)
+	sum := c.sum[b] - c.sum[a]
+	ss := math.Max(0, c.sumSq[b]-c.sumSq[a]-sum*sum/n)
+	if c.kind == CostMean {
+		return ss / c.sigma2
+	}
+	return n * math.Log(math.Max(ss/n, c.varFloor))
+}
+
+// pelt returns the change points that minimize the total cost plus
+// penalty per change point, with segments of at least minSize
+// values.
+func pelt(c *segCost, penalty float64, minSize int) []int {
+	n := len(c.sum) - 1
+	// f[t] is the minimum cost of segmenting [0, t), plus penalty.
+	// prev[t] is the start of the last segment in that
+	// segmentation.
+	f := make([]float64, n+1)
+	prev := make([]int, n+1)
+	f[0] = -penalty
+	for t := 1; t < minSize && t <= n; t++ {
+		f[t] = inf
+	}
+	// cands are the possible starts of the last segment. Once
+	// a candidate s can be pruned at t, it cannot be optimal for
+	// any t' >= t+minSize, since [t, t') is a valid segment, so
+	// expire is the t' at which to remove it, or 0.
+	type cand struct{ s, expire int }
+	var cands []cand
+	var costs []float64
+	for t := minSize; t <= n; t++ {
+		// The segmentation of [0, t-minSize) can now be
+		// extended by a segment of minSize.
+		if s := t - minSize; f[s] != inf {
+			cands = append(cands, cand{s, 0})
+		}
+		keep := cands[:0]
+		for _, c := range cands {
+			if c.expire == 0 || t < c.expire {
+				keep = append(keep, c)
+			}
+		}
+		cands = keep
+
+		f[t] = inf
+		costs = costs[:0]
+		for _, cd := range cands {
+			cost := f[cd.s] + c.cost(cd.s, t)
+			costs = append(costs, cost)
+			if cost+penalty < f[t] {
+				f[t], prev[t] = cost+penalty, cd.s
+			}
+		}
+		for i := range cands {
+			if cands[i].expire == 0 && costs[i] > f[t] {
+				cands[i].expire = t + minSize
+			}
+		}
+	}
+
+	var cps []int
+	for t := prev[n]; t > 0; t = prev[t] {
+		cps = append(cps, t)
+	}
+	sort.Ints(cps)
+	return cps
+}
+
+// binSeg returns the change points found by binary segmentation,
+// with segments of at least minSize values.
+func binSeg(c *segCost, penalty float64, minSize int) []int {
+	type split struct {
+		a, b, at int
+		gain     float64
+	}
+	best := func(a, b int) split {
+		s := split{a: a, b: b, at: -1, gain: -inf}
+		whole := c.cost(a, b)
+		for t := a + minSize; t <= b-minSize; t++ {
+			if g := whole - c.cost(a, t) - c.cost(t, b); g > s.gain {
+				s.at, s.gain = t, g
+			}
+		}
+		return s
+	}
+
+	n := len(c.sum) - 1
+	segs := []split{best(0, n)}
+	var cps []int
+	for {
+		bi := -1
+		for i, s := range segs {
+			if s.at >= 0 && (bi < 0 || s.gain > segs[bi].gain) {
+				bi = i
+			}
+		}
+		if bi < 0 || segs[bi].gain <= penalty {
+			break
+		}
+		s := segs[bi]
+		cps = append(cps, s.at)
+		segs[bi] = best(s.a, s.at)
+		segs = append(segs, best(s.at, s.b))
+	}
+	sort.Ints(cps)
+	return cps
+}
+
+// eDivisive returns the change points found by the E-divisive method,
+// with segments of at least minSize values.
+func eDivisive(xs []float64, minSize int, alpha float64, perms int, r *rand.Rand) []int {
+	shuffle := rand.Shuffle
+	if r != nil {
+		shuffle = r.Shuffle
+	}
+
+	// bestAll returns the best split of any segment of ys between
+	// bounds, and its statistic.
+	bestAll := func(ys []float64, bounds []int) (at int, q float64) {
+		at, q = -1, -inf
+		for i := 1; i < len(bounds); i++ {
+			a, b := bounds[i-1], bounds[i]
+			if t, tq := eDivisiveSplit(ys[a:b], minSize); t >= 0 && tq > q {
+				at, q = a+t, tq
+			}
+		}
+		return
+	}
+
+	bounds := []int{0, len(xs)}
+	perm := make([]float64, len(xs))
+	for {
+		at, q := bestAll(xs, bounds)
+		if at < 0 {
+			break
+		}
+
+		// Test the split by permuting the values within each
+		// segment, which preserves the change points found so
+		// far.
+		copy(perm, xs)
+		exceed := 0
+		for i := 0; i < perms; i++ {
+			for j := 1; j < len(bounds); j++ {
+				seg := perm[bounds[j-1]:bounds[j]]
+				shuffle(len(seg), func(a, b int) { seg[a], seg[b] = seg[b], seg[a] })
+			}
+			if _, pq := bestAll(perm, bounds); pq >= q {
+				exceed++
+			}
+		}
+		if p := float64(exceed+1) / float64(perms+1); p > alpha {
+			break
+		}
+
+		bounds = append(bounds, at)
+		sort.Ints(bounds)
+	}
+	return bounds[1 : len(bounds)-1]
+}
+
+// eDivisiveSplit returns the index t in [minSize, len(xs)-minSize]
+// that maximizes the scaled energy distance between xs[:t] and
+// xs[t:], and that distance. If there is no such t, it returns -1.
+func eDivisiveSplit(xs []float64, minSize int) (int, float64) {
+	n := len(xs)
+	if n < 2*minSize {
+		return -1, 0
+	}
+	// left[i] is the sum of |xs[i] - xs[k]| for k < i, and right[i]
+	// is the sum for k > i.
+	left := absDiffSums(xs)
+	rev := make([]float64, n)
+	for i, x := range xs {
+		rev[n-1-i] = x
+	}
+	right := absDiffSums(rev)
+	for i, j := 0, n-1; i < j; i, j = i+1, j-1 {
+		right[i], right[j] = right[j], right[i]
+	}
+
+	// Maintain the sums of distances within xs[:t] (wl), within
+	// xs[t:] (wr), and between them (between) as t increases.
+	wl, wr, between := 0.0, 0.0, 0.0
+	for _, l := range left {
+		wr += l
+	}
+	bestT, bestQ := -1, -inf
+	for t := 0; t <= n-minSize; t++ {
+		if t >= minSize {
+			nl, nr := float64(t), float64(n-t)
+			q := nl * nr / float64(n) * (2*between/(nl*nr) -
+				2*wl/(nl*(nl-1)) - 2*wr/(nr*(nr-1)))
+			if q > bestQ {
+				bestT, bestQ = t, q
+			}
+		}
+		// Move xs[t] from the right to the left.
+		wl += left[t]
+		wr -= right[t]
+		between += right[t] - left[t]
+	}
+	return bestT, bestQ
+}
+
+// absDiffSums returns s where s[i] is the sum of |xs[i] - xs[k]| for
+// all k < i. This takes O(n log n) time.
+func absDiffSums(xs []float64) []float64 {
+	n := len(xs)
+	// Compute the rank of each value, and use Fenwick trees
+	// indexed by rank to track the count and sum of the values
+	// before i with each rank.
+	order := make([]int, n)
+	for i := range order {
+		order[i] = i
+	}
+	sort.Slice(order, func(a, b int) bool { return xs[order[a]] < xs[order[b]] })
+	rank := make([]int, n)
+	for r, i := range order {
+		rank[i] = r + 1
+	}
+	cnt := make([]int, n+1)
+	sum := make([]float64, n+1)
+
+	res := make([]float64, n)
+	total := 0.0
+	for i, x := range xs {
+		// Count and sum the values before i with rank less
+		// than rank[i]. Values equal to x contribute 0 either
+		// way.
+		c, s := 0, 0.0
+		for j := rank[i]; j > 0; j -= j & -j {
+			c += cnt[j]
+			s += sum[j]
+		}
+		res[i] = float64(c)*x - s + (total - s) - float64(i-c)*x
+		for j := rank[i]; j <= n; j += j & -j {
+			cnt[j]++
+			sum[j] += x
+		}
+		total += x
+	}
+	return res
+}
@@ -0,0 +1,173 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package stats
+
+import (
+	"math"
+	"math/rand"
+	"reflect"
+	"testing"
+)
+
+// stepSeries returns a series with segments of the given lengths,
+// means, and standard deviations.
+func stepSeries(r *rand.Rand, lens []int, means, sds []float64) []float64 {
+	var xs []float64
+	for i, n := range lens {
+		for j := 0; j < n; j++ {
+			xs = append(xs, means[i]+sds[i]*r.NormFloat64())
+		}
+	}
+	return xs
+}
+
+func TestChangePoints(t *testing.T) {
+	r := rand.New(rand.NewSource(2))
+	meanShift := stepSeries(r, []int{100, 60, 140}, []float64{10, 13, 10}, []float64{1, 1, 1})
+	varShift := stepSeries(r, []int{150, 150}, []float64{0, 0}, []float64{1, 5})
+	flat := stepSeries(r, []int{200}, []float64{3}, []float64{1})
+
+	for _, test := range []struct {
+		d    ChangePointDetector
+		xs   []float64
+		want []int
+	}{
+		{ChangePointDetector{Method: ChangePointPELT}, meanShift, []int{100, 160}},
+		{ChangePointDetector{Method: ChangePointBinSeg}, meanShift, []int{100, 160}},
+		{ChangePointDetector{Method: ChangePointEDivisive, Rand: r}, meanShift, []int{100, 160}},
+		{ChangePointDetector{Method: ChangePointPELT, Cost: CostMeanVar}, varShift, []int{150}},
+		{ChangePointDetector{Method: ChangePointBinSeg, Cost: CostMeanVar}, varShift, []int{150}},
+		{ChangePointDetector{Method: ChangePointEDivisive, Rand: r}, varShift, []int{150}},
+		{ChangePointDetector{Method: ChangePointPELT}, flat, nil},
+		{ChangePointDetector{Method: ChangePointBinSeg, Cost: CostMeanVar}, flat, nil},
+		{ChangePointDetector{Method: ChangePointEDivisive, Rand: r}, flat, nil},
+	} {
+		res := test.d.Detect(test.xs)
+		if len(res.ChangePoints) != len(test.want) {
+			t.Errorf("%v/%v: got change points %v, want ~%v", test.d.Method, test.d.Cost, res.ChangePoints, test.want)
+			continue
+		}
+		for i, cp := range res.ChangePoints {
+			if cp < test.want[i]-3 || cp > test.want[i]+3 {
+				t.Errorf("%v/%v: got change points %v, want ~%v", test.d.Method, test.d.Cost, res.ChangePoints, test.want)
+				break
+			}
+		}
+		if len(res.Segments) != len(res.ChangePoints)+1 {
+			t.Errorf("%v/%v: got %d segments for %d change points", test.d.Method, test.d.Cost, len(res.Segments), len(res.ChangePoints))
+		}
+		n := 0
+		for _, s := range res.Segments {
+			n += len(s.Xs)
+		}
+		if n != len(test.xs) {
+			t.Errorf("%v/%v: segments have %d values, want %d", test.d.Method, test.d.Cost, n, len(test.xs))
+		}
+	}
+
+	// The segments summarize the series between change points.
+	res := (&ChangePointDetector{}).Detect(meanShift)
+	if m := res.Segments[1].Mean(); math.Abs(m-13) > 0.5 {
+		t.Errorf("mean of middle segment = %v, want ~13", m)
+	}
+	if res := (&ChangePointDetector{}).Detect([]float64{1, 2, 3}); res.ChangePoints != nil || len(res.Segments) != 1 {
+		t.Errorf("short series: got %+v", res)
+	}
+}
+
+func TestPELTOptimal(t *testing.T) {
+	// Compare PELT against all segmentations of short series.
+	r := rand.New(rand.NewSource(2))
+	for iter := 0; iter < 20; iter++ {
+		xs := stepSeries(r, []int{4, 5, 3}, []float64{0, 2 * r.NormFloat64(), 0}, []float64{1, 1 + r.Float64(), 1})
+		n := len(xs)
+		for _, kind := range []ChangePointCost{CostMean, CostMeanVar} {
+			c := newSegCost(xs, kind)
+			const penalty, minSize = 3.0, 2
+			total := func(cps []int) float64 {
+				sum, start := 0.0, 0
+				for _, cp := range append(cps, n) {
+					sum += c.cost(start, cp) + penalty
+					start = cp
+				}
+				return sum
+			}
+			var best []int
+			bestCost := inf
+			for mask := 0; mask < 1<<(n-1); mask++ {
+				var cps []int
+				ok, start := true, 0
+				for i := 1; i < n; i++ {
+					if mask&(1<<(i-1)) != 0 {
+						if i-start < minSize {
+							ok = false
+						}
+						cps = append(cps, i)
+						start = i
+					}
+				}
+				if !ok || n-start < minSize {
+					continue
+				}
+				if cost := total(cps); cost < bestCost {
+					best, bestCost = cps, cost
+				}
+			}
+			got := pelt(c, penalty, minSize)
+			if !aeq(total(got), bestCost) {
+				t.Errorf("%v: PELT found %v with cost %v, want %v with cost %v", kind, got, total(got), best, bestCost)
+			}
+		}
+	}
+}
+
+func TestEDivisiveSplit(t *testing.T) {
+	r := rand.New(rand.NewSource(3))
+	xs := make([]float64, 30)
+	for i := range xs {
+		// Include ties.
+		xs[i] = math.Floor(r.NormFloat64() * 3)
+		if i >= 12 {
+			xs[i] += 2
+		}
+	}
+
+	want := make([]float64, len(xs))
+	for i := range xs {
+		for k := 0; k < i; k++ {
+			want[i] += math.Abs(xs[i] - xs[k])
+		}
+	}
+	if got := absDiffSums(xs); !reflect.DeepEqual(got, want) {
+		t.Errorf("absDiffSums = %v, want %v", got, want)
+	}
+
+	// Compute the E-divisive statistic directly.
+	meanDist := func(a, b []float64, same bool) float64 {
+		sum, cnt := 0.0, 0
+		for i := range a {
+			for j := range b {
+				if same && j <= i {
+					continue
+				}
+				sum += math.Abs(a[i] - b[j])
+				cnt++
+			}
+		}
+		return sum / float64(cnt)
+	}
+	bestT, bestQ := -1, -inf
+	for t := 3; t <= len(xs)-3; t++ {
+		x, y := xs[:t], xs[t:]
+		nl, nr := float64(len(x)), float64(len(y))
+		q := nl * nr / (nl + nr) * (2*meanDist(x, y, false) - meanDist(x, x, true) - meanDist(y, y, true))
+		if q > bestQ {
+			bestT, bestQ = t, q
+		}
+	}
+	if gotT, gotQ := eDivisiveSplit(xs, 3); gotT != bestT || !aeq(gotQ, bestQ) {
+		t.Errorf("eDivisiveSplit = %v, %v, want %v, %v", gotT, gotQ, bestT, bestQ)
+	}
+}
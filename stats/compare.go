@@ -0,0 +1,401 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package stats
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/aclements/go-moremath/mathx"
+)
+
+// A Comparer compares groups of samples, such as the results of a
+// suite of benchmarks before and after a change. For each row (such
+// as a benchmark), it summarizes the sample in each group and tests
+// whether each group differs from the first group, the baseline. It
+// also summarizes the overall change across rows using geometric
+// means.
+//
+// All fields are optional and have reasonable defaults.
+type Comparer struct {
+	// Test is the statistical test used to compare samples.
+	Test CompareTest
+
+	// Alpha is the significance level for the tests. If this is
+	// 0, it uses 0.05.
+	Alpha float64
+
+	// Confidence is the confidence level of the intervals of each
+	// center and delta. If this is 0, it uses 0.95.
+	Confidence float64
+
+	// Outliers, if non-nil, is used to remove outliers from each
+	// sample before summarizing and testing it. For example,
+	//
+	//	func(s Sample) *OutlierResult { return s.TukeyOutliers(1.5) }
+	Outliers func(Sample) *OutlierResult
+}
+
+// A CompareTest is a statistical test used by a Comparer. The test
+// also determines how each sample is summarized.
+type CompareTest int
+
+//go:generate stringer -type=CompareTest
+
+const (
+	// CompareUTest summarizes each sample by its median, with a
+	// distribution-free confidence interval, and compares samples
+	// using the Mann-Whitney U-test. The confidence interval of
+	// each delta is derived from the Hodges-Lehmann interval of
+	// the shift in log values, and the geometric mean delta is
+	// tested using the sign test. This makes few assumptions and
+	// is robust to outliers, but needs at least 4 values in each
+	// sample to detect a difference at α = 0.05.
+	CompareUTest CompareTest = iota
+
+	// CompareTTest summarizes each sample by its mean and compares
+	// samples using Welch's t-test. The confidence interval of
+	// each delta is Fieller's interval of the ratio of the means,
+	// and the geometric mean delta is tested using a one-sample
+	// t-test of the log ratios. This is more powerful than
+	// CompareUTest for normally distributed data, but sensitive
+	// to outliers.
+	CompareTTest
+)
+
+// A SampleGroup is a labeled group of samples, such as the results
+// of a suite of benchmarks at one commit.
+type SampleGroup struct {
+	Label string
+	Rows  []LabeledSample
+}
+
+// A LabeledSample is a Sample with a label, such as the name of the
+// benchmark it measures. The Sample must be unweighted.
+type LabeledSample struct {
+	Label  string
+	Sample Sample
+}
+
+// A Comparison is the result of comparing groups of samples.
+type Comparison struct {
+	// Groups are the labels of the compared groups. The first
+	// group is the baseline.
+	Groups []string
+
+	// Rows are the comparisons of each row, in the order each
+	// row label first appears in the groups.
+	Rows []*ComparisonRow
+
+	// Geomean summarizes all rows that have a positive center in
+	// every group. The center of each of its cells is the
+	// geometric mean of the centers of these rows, and each delta
+	// is the geometric mean of the ratios of these centers to the
+	// baseline. If there are no such rows, Geomean is nil.
+	Geomean *ComparisonRow
+}
+
+// A ComparisonRow is the comparison of one row across groups.
+type ComparisonRow struct {
+	Label string
+
+	// Cells summarize the sample of this row in each group.
+	// Cells[i] is nil if group i has no sample for this row.
+	Cells []*ComparisonCell
+
+	// Deltas compare the sample of this row in each group to the
+	// baseline group. Deltas[0] is always nil, and Deltas[i] is
+	// nil if either Cells[0] or Cells[i] is nil.
+	Deltas []*ComparisonDelta
+}
+
+// A ComparisonCell summarizes one sample.
+type ComparisonCell struct {
+	// Sample is the sample, after removing outliers.
+	Sample Sample
+
+	// Outliers is the number of outliers removed from the sample.
+	Outliers int
+
+	// Center is the median or mean of the sample, depending on
+	// the CompareTest, and [Lo, Hi] is its confidence interval.
+	// The bounds may be infinite if the sample is too small.
+	Center, Lo, Hi float64
+
+	// Warnings are problems with this summary, such as too few
+	// samples for a confidence interval.
+	Warnings []string
+}
+
+// Spread returns the relative half-width of c's confidence interval.
+// This is the larger distance from Center to Lo or Hi, divided by
+// |Center|, and is conventionally shown as ±x%.
+func (c *ComparisonCell) Spread() float64 {
+	return math.Max(c.Hi-c.Center, c.Center-c.Lo) / math.Abs(c.Center)
+}
+
+// A ComparisonDelta compares a sample to the corresponding baseline
+// sample.
+type ComparisonDelta struct {
+	// Delta is the relative change in the center from the
+	// baseline: Center / baseline Center - 1. Multiply by 100 to
+	// get a percent change.
+	Delta float64
+
+	// Lo and Hi are the bounds of the confidence interval of
+	// Delta. These are NaN if the interval cannot be computed,
+	// such as for samples with non-positive values.
+	Lo, Hi float64
+
+	// P is the p-value of the test of the null hypothesis that
+	// the sample and the baseline have the same center. This is
+	// NaN if the test could not be performed.
+	P float64
+
+	// Significant indicates that P < Alpha.
+	Significant bool
+
+	// Warnings are problems with this comparison, such as too
+	// few samples to detect a difference.
+	Warnings []string
+}
+
+// String returns Delta as a signed percent change, or "~" if it is
+// not significant.
+func (d *ComparisonDelta) String() string {
+	if !d.Significant {
+		return "~"
+	}
+	return fmt.Sprintf("%+.2f%%", d.Delta*100)
+}
+
+// Compare compares groups, using the first group as the baseline.
+//
+// The samples in groups must not be weighted: the tests and intervals
+// used by CompareUTest require unweighted samples, so Compare panics
+// if any sample has Weights, regardless of Test.
+func (c *Comparer) Compare(groups []SampleGroup) *Comparison {
+	alpha, confidence := c.Alpha, c.Confidence
+	if alpha == 0 {
+		alpha = 0.05
+	}
+	if confidence == 0 {
+		confidence = 0.95
+	}
+
+	res := &Comparison{}
+	rowIndex := make(map[string]int)
+	for gi, g := range groups {
+		res.Groups = append(res.Groups, g.Label)
+		for _, ls := range g.Rows {
+			if ls.Sample.Weights != nil {
+				panic("Cannot compare weighted samples")
+			}
+			ri, ok := rowIndex[ls.Label]
+			if !ok {
+				ri = len(res.Rows)
+				rowIndex[ls.Label] = ri
+				res.Rows = append(res.Rows, &ComparisonRow{
+					Label:  ls.Label,
+					Cells:  make([]*ComparisonCell, len(groups)),
+					Deltas: make([]*ComparisonDelta, len(groups)),
+				})
+			}
+			res.Rows[ri].Cells[gi] = c.summarize(ls.Sample, confidence)
+		}
+	}
+
+	for _, row := range res.Rows {
+		base := row.Cells[0]
+		for gi := 1; gi < len(groups); gi++ {
+			if base != nil && row.Cells[gi] != nil {
+				row.Deltas[gi] = c.compare(base, row.Cells[gi], alpha, confidence)
+			}
+		}
+	}
+
+	res.Geomean = c.geomean(res, alpha, confidence)
+	return res
+}
+
+// summarize returns the summary of sample s.
+func (c *Comparer) summarize(s Sample, confidence float64) *ComparisonCell {
+	cell := &ComparisonCell{Sample: s}
+	if c.Outliers != nil {
+		o := c.Outliers(s)
+		cell.Sample, cell.Outliers = o.Cleaned, len(o.Indexes)
+	}
+	s = cell.Sample
+
+	switch c.Test {
+	case CompareUTest:
+		if len(s.Xs) == 0 {
+			cell.Center, cell.Lo, cell.Hi = nan, -inf, inf
+			break
+		}
+		ci := QuantileCI(len(s.Xs), 0.5, confidence)
+		cell.Center, cell.Lo, cell.Hi = ci.SampleCI(s)
+	case CompareTTest:
+		cell.Center, cell.Lo, cell.Hi = s.MeanCI(confidence)
+	default:
+		panic("unknown CompareTest")
+	}
+	if math.IsInf(cell.Lo, 0) || math.IsInf(cell.Hi, 0) {
+		cell.Warnings = append(cell.Warnings, fmt.Sprintf("too few samples for %v%% confidence interval", confidence*100))
+	}
+	return cell
+}
+
+// compare returns the comparison of cell to the baseline cell base.
+func (c *Comparer) compare(base, cell *ComparisonCell, alpha, confidence float64) *ComparisonDelta {
+	d := &ComparisonDelta{Delta: cell.Center/base.Center - 1, Lo: nan, Hi: nan, P: nan}
+	x1, x2 := cell.Sample, base.Sample
+
+	var err error
+	switch c.Test {
+	case CompareUTest:
+		n1, n2 := len(x1.Xs), len(x2.Xs)
+		if n1 > 0 && n2 > 0 && 2/mathx.Choose(n1+n2, n1) > alpha {
+			d.Warnings = append(d.Warnings, fmt.Sprintf("too few samples to detect a difference at α=%v", alpha))
+		}
+		var res *MannWhitneyUTestResult
+		if res, err = MannWhitneyUTest(x1.Xs, x2.Xs, LocationDiffers); err == nil {
+			d.P = res.P
+		}
+		// The Hodges-Lehmann interval of the shift in log
+		// values is an interval of the ratio of locations.
+		if l1, l2 := logs(x1.Xs), logs(x2.Xs); l1 != nil && l2 != nil {
+			if hl, err := HodgesLehmann(l1, l2, confidence); err == nil {
+				d.Lo, d.Hi = math.Expm1(hl.Lo), math.Expm1(hl.Hi)
+			}
+		}
+
+	case CompareTTest:
+		var res *TTestResult
+		if res, err = TwoSampleWelchTTest(x1, x2, LocationDiffers); err == nil {
+			d.P = res.P
+		}
+		if err != ErrSampleSize {
+			_, lo, hi := FiellerCI(x1, x2, confidence)
+			d.Lo, d.Hi = lo-1, hi-1
+		}
+	}
+
+	switch err {
+	case nil:
+	case ErrSamplesEqual:
+		d.P = 1
+		d.Warnings = append(d.Warnings, "all samples are equal")
+	case ErrZeroVariance:
+		if x1.Mean() == x2.Mean() {
+			d.P = 1
+			d.Warnings = append(d.Warnings, "all samples are equal")
+		} else {
+			d.Warnings = append(d.Warnings, "samples have zero variance")
+		}
+	case ErrSampleSize:
+		d.Warnings = append(d.Warnings, "too few samples to test")
+	default:
+		d.Warnings = append(d.Warnings, err.Error())
+	}
+	d.Significant = d.P < alpha
+	return d
+}
+
+// geomean returns the geometric mean row of res, or nil if no rows
+// have a positive center in every group.
+func (c *Comparer) geomean(res *Comparison, alpha, confidence float64) *ComparisonRow {
+	ng := len(res.Groups)
+	centers := make([][]float64, ng)
+rows:
+	for _, row := range res.Rows {
+		for _, cell := range row.Cells {
+			if cell == nil || !(cell.Center > 0) || math.IsInf(cell.Center, 1) {
+				continue rows
+			}
+		}
+		for gi, cell := range row.Cells {
+			centers[gi] = append(centers[gi], cell.Center)
+		}
+	}
+	if ng == 0 || len(centers[0]) == 0 {
+		return nil
+	}
+
+	g := &ComparisonRow{
+		Label:  "geomean",
+		Cells:  make([]*ComparisonCell, ng),
+		Deltas: make([]*ComparisonDelta, ng),
+	}
+	for gi := range centers {
+		g.Cells[gi] = &ComparisonCell{
+			Sample: Sample{Xs: centers[gi]},
+			Center: GeoMean(centers[gi]),
+			Lo:     nan,
+			Hi:     nan,
+		}
+	}
+
+	n := len(centers[0])
+	for gi := 1; gi < ng; gi++ {
+		ratios := make([]float64, n)
+		logRatios := make([]float64, n)
+		for i := range ratios {
+			ratios[i] = centers[gi][i] / centers[0][i]
+			logRatios[i] = math.Log(ratios[i])
+		}
+		mean, lo, hi := GeoMeanCI(ratios, confidence)
+		d := &ComparisonDelta{Delta: mean - 1, Lo: lo - 1, Hi: hi - 1, P: nan}
+
+		var err error
+		switch c.Test {
+		case CompareUTest:
+			var res *BinomialTestResult
+			if res, err = SignTest(logRatios, make([]float64, n), LocationDiffers); err == nil {
+				d.P = res.P
+			}
+		case CompareTTest:
+			if n < 2 {
+				err = ErrSampleSize
+				break
+			}
+			var res *TTestResult
+			if res, err = OneSampleTTest(Sample{Xs: logRatios}, 0, LocationDiffers); err == nil {
+				d.P = res.P
+			}
+		}
+		switch err {
+		case nil:
+		case ErrSamplesEqual:
+			d.P = 1
+		case ErrZeroVariance:
+			if logRatios[0] == 0 {
+				d.P = 1
+			} else {
+				d.Warnings = append(d.Warnings, "all ratios are equal")
+			}
+		case ErrSampleSize:
+			d.Warnings = append(d.Warnings, "too few rows to test")
+		default:
+			d.Warnings = append(d.Warnings, err.Error())
+		}
+		d.Significant = d.P < alpha
+		g.Deltas[gi] = d
+	}
+	return g
+}
+
+// logs returns the logarithms of xs, or nil if any value is not
+// positive.
+func logs(xs []float64) []float64 {
+	ls := make([]float64, len(xs))
+	for i, x := range xs {
+		if !(x > 0) {
+			return nil
+		}
+		ls[i] = math.Log(x)
+	}
+	return ls
+}
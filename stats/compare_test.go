@@ -0,0 +1,171 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package stats
+
+import (
+	"math"
+	"testing"
+)
+
+func TestCompare(t *testing.T) {
+	old := SampleGroup{Label: "old", Rows: []LabeledSample{
+		{"Fast", Sample{Xs: []float64{10, 11, 10.5, 10.2, 10.8, 10.4}}},
+		{"Slow", Sample{Xs: []float64{100, 102, 98, 101, 99, 100}}},
+		{"Same", Sample{Xs: []float64{5, 5, 5, 5}}},
+		{"OldOnly", Sample{Xs: []float64{1, 2}}},
+	}}
+	cur := SampleGroup{Label: "new", Rows: []LabeledSample{
+		{"Slow", Sample{Xs: []float64{101, 99, 100, 102, 98, 100}}},
+		{"Fast", Sample{Xs: []float64{8, 8.4, 8.2, 8.1, 8.3, 8.2}}},
+		{"Same", Sample{Xs: []float64{5, 5, 5, 5}}},
+		{"NewOnly", Sample{Xs: []float64{1, 2, 3}}},
+	}}
+
+	for test, wantFast := range map[CompareTest]string{
+		CompareUTest: "-21.53%",
+		CompareTTest: "-21.78%",
+	} {
+		c := (&Comparer{Test: test}).Compare([]SampleGroup{old, cur})
+		if len(c.Groups) != 2 || c.Groups[0] != "old" || c.Groups[1] != "new" {
+			t.Errorf("%v: Groups = %v", test, c.Groups)
+		}
+		var labels []string
+		for _, row := range c.Rows {
+			labels = append(labels, row.Label)
+		}
+		if want := []string{"Fast", "Slow", "Same", "OldOnly", "NewOnly"}; !sliceEq(labels, want) {
+			t.Errorf("%v: rows = %v, want %v", test, labels, want)
+		}
+
+		fast := c.Rows[0]
+		oc, nc := fast.Cells[0], fast.Cells[1]
+		if test == CompareUTest {
+			if !aeq(oc.Center, 10.45) || !aeq(nc.Center, 8.2) {
+				t.Errorf("%v: Fast medians = %v, %v", test, oc.Center, nc.Center)
+			}
+		} else if !aeq(oc.Center, Mean(old.Rows[0].Sample.Xs)) {
+			t.Errorf("%v: Fast mean = %v", test, oc.Center)
+		}
+		if !(oc.Lo <= oc.Center && oc.Center <= oc.Hi) || !(oc.Spread() > 0) {
+			t.Errorf("%v: bad Fast CI %v [%v, %v]", test, oc.Center, oc.Lo, oc.Hi)
+		}
+		d := fast.Deltas[1]
+		if fast.Deltas[0] != nil {
+			t.Errorf("%v: baseline delta is not nil", test)
+		}
+		if !aeq(d.Delta, nc.Center/oc.Center-1) || !d.Significant || !(d.P < 0.05) {
+			t.Errorf("%v: Fast delta = %+v", test, d)
+		}
+		if !(d.Lo <= d.Delta && d.Delta <= d.Hi && d.Hi < 0) {
+			t.Errorf("%v: Fast delta CI [%v, %v] does not contain %v or is not negative", test, d.Lo, d.Hi, d.Delta)
+		}
+		if s := d.String(); s != wantFast {
+			t.Errorf("%v: Fast delta String = %q, want %q", test, s, wantFast)
+		}
+
+		if d := c.Rows[1].Deltas[1]; d.Significant || d.String() != "~" {
+			t.Errorf("%v: Slow delta = %+v, want not significant", test, d)
+		}
+		if d := c.Rows[2].Deltas[1]; d.P != 1 || d.Significant || len(d.Warnings) == 0 {
+			t.Errorf("%v: Same delta = %+v, want P = 1 with warning", test, d)
+		}
+		if row := c.Rows[3]; row.Cells[1] != nil || row.Deltas[1] != nil {
+			t.Errorf("%v: OldOnly row = %+v", test, row)
+		} else if hasWarn := len(row.Cells[0].Warnings) != 0; hasWarn != (test == CompareUTest) {
+			// Two values are too few for a 95% median CI, but
+			// not for a mean CI.
+			t.Errorf("%v: OldOnly cell warnings = %v", test, row.Cells[0].Warnings)
+		}
+		if row := c.Rows[4]; row.Cells[0] != nil || row.Deltas[1] != nil {
+			t.Errorf("%v: NewOnly row = %+v", test, row)
+		}
+
+		// The geomean includes Fast, Slow, and Same.
+		g := c.Geomean
+		if g == nil {
+			t.Fatalf("%v: no geomean", test)
+		}
+		ratios := make([]float64, 3)
+		for i := range ratios {
+			ratios[i] = c.Rows[i].Cells[1].Center / c.Rows[i].Cells[0].Center
+		}
+		if want := GeoMean(ratios) - 1; !aeq(g.Deltas[1].Delta, want) {
+			t.Errorf("%v: geomean delta = %v, want %v", test, g.Deltas[1].Delta, want)
+		}
+		if want := GeoMean([]float64{oc.Center, c.Rows[1].Cells[0].Center, 5}); !aeq(g.Cells[0].Center, want) {
+			t.Errorf("%v: geomean center = %v, want %v", test, g.Cells[0].Center, want)
+		}
+		if g.Deltas[1].Significant {
+			t.Errorf("%v: geomean delta of 3 rows is significant", test)
+		}
+	}
+
+	// With only 3 values per sample, the U-test cannot detect a
+	// difference at α = 0.05.
+	small := []SampleGroup{
+		{"a", []LabeledSample{{"x", Sample{Xs: []float64{1, 2, 3}}}}},
+		{"b", []LabeledSample{{"x", Sample{Xs: []float64{10, 11, 12}}}}},
+	}
+	c := (&Comparer{}).Compare(small)
+	if d := c.Rows[0].Deltas[1]; d.Significant || len(d.Warnings) == 0 {
+		t.Errorf("small U-test delta = %+v, want not significant with warning", d)
+	}
+	if d := c.Geomean.Deltas[1]; d.Significant || len(d.Warnings) != 0 {
+		t.Errorf("small geomean delta = %+v", d)
+	}
+	c = (&Comparer{Alpha: 0.2}).Compare(small)
+	if d := c.Rows[0].Deltas[1]; !d.Significant || len(d.Warnings) != 0 {
+		t.Errorf("small U-test delta at α = 0.2 = %+v, want significant", d)
+	}
+
+	// Outliers are removed before summarizing.
+	withOutlier := []SampleGroup{
+		{"a", []LabeledSample{{"x", Sample{Xs: []float64{10, 10.1, 9.9, 10, 10.2, 9.8, 100}}}}},
+		{"b", []LabeledSample{{"x", Sample{Xs: []float64{10, 10.1, 9.9, 10, 10.2, 9.8}}}}},
+	}
+	c = (&Comparer{
+		Test:     CompareTTest,
+		Outliers: func(s Sample) *OutlierResult { return s.TukeyOutliers(1.5) },
+	}).Compare(withOutlier)
+	if cell := c.Rows[0].Cells[0]; cell.Outliers != 1 || len(cell.Sample.Xs) != 6 || !aeq(cell.Center, 10) {
+		t.Errorf("cell with outlier = %+v", cell)
+	}
+	if d := c.Rows[0].Deltas[1]; math.Abs(d.Delta) > 1e-12 {
+		t.Errorf("delta after removing outlier = %v, want 0", d.Delta)
+	}
+
+	if c := (&Comparer{}).Compare(nil); c.Geomean != nil || len(c.Rows) != 0 {
+		t.Errorf("empty comparison = %+v", c)
+	}
+}
+
+func sliceEq(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+func TestCompareWeighted(t *testing.T) {
+	groups := []SampleGroup{
+		{"a", []LabeledSample{{"x", Sample{Xs: []float64{1, 2, 3}}}}},
+		{"b", []LabeledSample{{"x", Sample{Xs: []float64{1, 2, 3}, Weights: []float64{1, 2, 1}}}}},
+	}
+	for _, test := range []CompareTest{CompareUTest, CompareTTest} {
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Errorf("%v: Compare of weighted sample did not panic", test)
+				}
+			}()
+			(&Comparer{Test: test}).Compare(groups)
+		}()
+	}
+}
@@ -0,0 +1,412 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package stats
+
+import (
+	"math"
+	"math/rand"
+	"sort"
+
+	"gonum.org/v1/gonum/dsp/fourier"
+)
+
+// ConvolveGridSize is the approximate number of grid points used by
+// Convolve to represent the distribution of the sum.
+var ConvolveGridSize = 1 << 14
+
+// convolveTail is the probability in each infinite tail of a
+// component distribution that Convolve and ConvolveDiscrete may
+// ignore.
+const convolveTail = 1e-10
+
+// convolveBoundsTail is the probability in each inexact tail of a
+// convolved distribution that lies outside its Bounds. This is the
+// same as the tail outside ±3σ of a normal distribution.
+const convolveBoundsTail = 0.0013498980316301
+
+// ConvolvedDist is the distribution of the sum of independent
+// continuous random variables, represented on a regular grid. Use
+// Convolve to construct a ConvolvedDist.
+//
+// The density of a ConvolvedDist is piecewise constant between grid
+// points and its CDF is piecewise linear, so it is consistent with
+// its own PDF. If a bound of the support is exact, the grid cell
+// containing it is cut off at that bound, so the CDF is continuous
+// there.
+type ConvolvedDist struct {
+	// x0 is the center of the first grid cell and delta is the
+	// width of each cell. The first and last cells may be cut off
+	// by supLo and supHi; see cell.
+	x0, delta float64
+
+	// mass[i] is the probability of the cell centered at
+	// x0+i*delta, and cum[i] is the sum of mass[:i+1].
+	mass, cum []float64
+
+	// supLo and supHi are the exact bounds of the support of the
+	// sum, or ±Inf if the support is unbounded.
+	supLo, supHi float64
+
+	lo, hi float64
+}
+
+// Convolve returns the distribution of the sum of independent random
+// variables distributed according to dists. It discretizes each
+// distribution on a common grid and convolves them using the fast
+// Fourier transform.
+//
+// If every distribution has finite support, the support of the
+// result is exactly the sum of their supports. Otherwise, each
+// infinite tail is cut off where its probability is negligible.
+//
+// Convolve panics if dists is empty.
+func Convolve(dists ...Dist) *ConvolvedDist {
+	if len(dists) == 0 {
+		panic("Convolve requires at least one distribution")
+	}
+
+	// Find the range of each distribution.
+	los, his := make([]float64, len(dists)), make([]float64, len(dists))
+	supLo, supHi := 0.0, 0.0
+	width := 0.0
+	for i, d := range dists {
+		l, h, loExact, hiExact := tailBounds(d, convolveTail)
+		if !loExact {
+			supLo = -inf
+		}
+		if !hiExact {
+			supHi = inf
+		}
+		supLo, supHi = supLo+l, supHi+h
+		los[i], his[i] = l, h
+		width += h - l
+	}
+
+	// Discretize each distribution into cells of width delta
+	// centered on lo + k*delta.
+	delta := width / float64(ConvolveGridSize-len(dists))
+	var res []float64
+	x0 := 0.0
+	for i, d := range dists {
+		n := int(math.Ceil((his[i]-los[i])/delta)) + 1
+		mass := make([]float64, n)
+		prev, total := d.CDF(los[i]-delta/2), 0.0
+		for k := range mass {
+			next := d.CDF(los[i] + (float64(k)+0.5)*delta)
+			mass[k] = math.Max(0, next-prev)
+			total += mass[k]
+			prev = next
+		}
+		for k := range mass {
+			mass[k] /= total
+		}
+		x0 += los[i]
+		if res == nil {
+			res = mass
+		} else {
+			res = convolveFFT(res, mass)
+		}
+	}
+
+	// If the upper bound is exact, discretization may spread a
+	// little mass into cells beyond it. Fold that mass into the
+	// cell containing the bound.
+	if !math.IsInf(supHi, 1) {
+		last := int(math.Ceil((supHi-x0)/delta - 0.5))
+		if last >= 0 && last < len(res)-1 {
+			for _, m := range res[last+1:] {
+				res[last] += m
+			}
+			res = res[:last+1]
+		}
+	}
+
+	cd := &ConvolvedDist{x0: x0, delta: delta, mass: res, cum: make([]float64, len(res)), supLo: supLo, supHi: supHi}
+	sum := 0.0
+	for i, m := range res {
+		sum += m
+		cd.cum[i] = sum
+	}
+
+	// Compute bounds.
+	cd.lo, cd.hi = cd.supLo, cd.supHi
+	if math.IsInf(cd.lo, 0) {
+		cd.lo = cd.InvCDF(convolveBoundsTail)
+	}
+	if math.IsInf(cd.hi, 0) {
+		cd.hi = cd.InvCDF(1 - convolveBoundsTail)
+	}
+	return cd
+}
+
+// convolveFFT returns the convolution of a and b, normalized to sum
+// to 1.
+func convolveFFT(a, b []float64) []float64 {
+	n := len(a) + len(b) - 1
+	size := 1
+	for size < n {
+		size *= 2
+	}
+	fft := fourier.NewFFT(size)
+	pa, pb := make([]float64, size), make([]float64, size)
+	copy(pa, a)
+	copy(pb, b)
+	ca := fft.Coefficients(nil, pa)
+	cb := fft.Coefficients(nil, pb)
+	for i := range ca {
+		ca[i] *= cb[i]
+	}
+	out := fft.Sequence(pa, ca)[:n]
+
+	// Clean up rounding error, which can produce small negative
+	// values.
+	total := 0.0
+	for i, x := range out {
+		out[i] = math.Max(0, x)
+		total += out[i]
+	}
+	for i := range out {
+		out[i] /= total
+	}
+	return out
+}
+
+// cell returns the bounds of grid cell i, cut off at the support.
+func (d *ConvolvedDist) cell(i int) (lo, hi float64) {
+	lo = d.x0 + (float64(i)-0.5)*d.delta
+	hi = lo + d.delta
+	return math.Max(lo, d.supLo), math.Min(hi, d.supHi)
+}
+
+func (d *ConvolvedDist) PDF(x float64) float64 {
+	if x < d.supLo || x > d.supHi {
+		return 0
+	}
+	i := int(math.Floor((x-d.x0)/d.delta + 0.5))
+	if i < 0 || i >= len(d.mass) {
+		return 0
+	}
+	lo, hi := d.cell(i)
+	return d.mass[i] / (hi - lo)
+}
+
+func (d *ConvolvedDist) CDF(x float64) float64 {
+	// Position in units of cells from the left edge of the grid.
+	pos := (x-d.x0)/d.delta + 0.5
+	if pos <= 0 || x < d.supLo {
+		return 0
+	} else if pos >= float64(len(d.mass)) || x >= d.supHi {
+		return 1
+	}
+	i := int(pos)
+	below := 0.0
+	if i > 0 {
+		below = d.cum[i-1]
+	}
+	lo, hi := d.cell(i)
+	frac := math.Max(0, math.Min(1, (x-lo)/(hi-lo)))
+	return math.Min(1, below+frac*d.mass[i])
+}
+
+func (d *ConvolvedDist) InvCDF(y float64) float64 {
+	if y < 0 || y > 1 {
+		return nan
+	} else if y == 0 {
+		return d.supLo
+	} else if y == 1 {
+		return d.supHi
+	}
+	i := sort.SearchFloat64s(d.cum, y)
+	if i >= len(d.mass) {
+		i = len(d.mass) - 1
+	}
+	below := 0.0
+	if i > 0 {
+		below = d.cum[i-1]
+	}
+	frac := 0.0
+	if d.mass[i] > 0 {
+		frac = (y - below) / d.mass[i]
+	}
+	lo, hi := d.cell(i)
+	x := lo + frac*(hi-lo)
+	return math.Max(d.supLo, math.Min(d.supHi, x))
+}
+
+// Bounds returns the bounds of the sum. If all of the distributions
+// passed to Convolve have an exact lower (or upper) bound, the
+// corresponding bound of the sum is also exact.
+func (d *ConvolvedDist) Bounds() (float64, float64) {
+	return d.lo, d.hi
+}
+
+func (d *ConvolvedDist) Rand(r *rand.Rand) float64 {
+	var u float64
+	if r == nil {
+		u = rand.Float64()
+	} else {
+		u = r.Float64()
+	}
+	return d.InvCDF(u)
+}
+
+// Mean returns the mean of the discretized distribution.
+func (d *ConvolvedDist) Mean() float64 {
+	mean := 0.0
+	for i, m := range d.mass {
+		lo, hi := d.cell(i)
+		mean += m * (lo + hi) / 2
+	}
+	return mean
+}
+
+// DiscreteConvolvedDist is the distribution of the sum of
+// independent discrete random variables. Use ConvolveDiscrete to
+// construct a DiscreteConvolvedDist.
+type DiscreteConvolvedDist struct {
+	// k0 is the index of the first point of pmf in units of
+	// step.
+	k0   int
+	step float64
+
+	pmf, cum []float64
+
+	lo, hi float64
+}
+
+// ConvolveDiscrete returns the distribution of the sum of independent
+// random variables distributed according to dists. It computes the
+// PMF of the sum exactly by direct summation. All of the
+// distributions must have the same Step.
+//
+// If every distribution has finite support, the result is exact.
+// Otherwise, each infinite tail is cut off where its probability is
+// negligible.
+//
+// ConvolveDiscrete panics if dists is empty.
+func ConvolveDiscrete(dists ...DiscreteDist) *DiscreteConvolvedDist {
+	if len(dists) == 0 {
+		panic("ConvolveDiscrete requires at least one distribution")
+	}
+	step := dists[0].Step()
+	res := &DiscreteConvolvedDist{step: step}
+	loExact, hiExact := true, true
+	for i, d := range dists {
+		if d.Step() != step {
+			panic("ConvolveDiscrete distributions have different steps")
+		}
+		l, h, le, he := tailBounds(d, convolveTail)
+		loExact, hiExact = loExact && le, hiExact && he
+		k0 := int(math.Round(l / step))
+		pmf := make([]float64, int(math.Round(h/step))-k0+1)
+		for k := range pmf {
+			pmf[k] = d.PMF(float64(k0+k) * step)
+		}
+		if i == 0 {
+			res.k0, res.pmf = k0, pmf
+			continue
+		}
+		res.k0 += k0
+		res.pmf = convolveDirect(res.pmf, pmf)
+	}
+
+	res.cum = make([]float64, len(res.pmf))
+	sum := 0.0
+	for i, p := range res.pmf {
+		sum += p
+		res.cum[i] = sum
+	}
+
+	res.lo, res.hi = float64(res.k0)*step, float64(res.k0+len(res.pmf)-1)*step
+	if !loExact {
+		res.lo = res.quantile(convolveBoundsTail)
+	}
+	if !hiExact {
+		res.hi = res.quantile(1 - convolveBoundsTail)
+	}
+	return res
+}
+
+// convolveDirect returns the convolution of a and b.
+func convolveDirect(a, b []float64) []float64 {
+	out := make([]float64, len(a)+len(b)-1)
+	for i, x := range a {
+		if x == 0 {
+			continue
+		}
+		for j, y := range b {
+			out[i+j] += x * y
+		}
+	}
+	return out
+}
+
+// quantile returns the smallest point x such that CDF(x) >= y,
+// normalizing for any tail probability that was cut off.
+func (d *DiscreteConvolvedDist) quantile(y float64) float64 {
+	i := sort.SearchFloat64s(d.cum, y*d.cum[len(d.cum)-1])
+	if i >= len(d.cum) {
+		i = len(d.cum) - 1
+	}
+	return float64(d.k0+i) * d.step
+}
+
+func (d *DiscreteConvolvedDist) PMF(x float64) float64 {
+	i := int(math.Floor(x/d.step)) - d.k0
+	if i < 0 || i >= len(d.pmf) {
+		return 0
+	}
+	return d.pmf[i]
+}
+
+func (d *DiscreteConvolvedDist) CDF(x float64) float64 {
+	i := int(math.Floor(x/d.step)) - d.k0
+	if i < 0 {
+		return 0
+	} else if i >= len(d.cum)-1 {
+		return 1
+	}
+	return math.Min(1, d.cum[i])
+}
+
+func (d *DiscreteConvolvedDist) InvCDF(y float64) float64 {
+	if y < 0 || y > 1 {
+		return nan
+	} else if y == 0 {
+		return float64(d.k0) * d.step
+	}
+	return d.quantile(y)
+}
+
+func (d *DiscreteConvolvedDist) Rand(r *rand.Rand) float64 {
+	var u float64
+	if r == nil {
+		u = rand.Float64()
+	} else {
+		u = r.Float64()
+	}
+	// Use (0, 1] so we never return a point with zero probability.
+	return d.quantile(1 - u)
+}
+
+// Bounds returns the bounds of the sum. If all of the distributions
+// passed to ConvolveDiscrete have an exact lower (or upper) bound,
+// the corresponding bound of the sum is also exact.
+func (d *DiscreteConvolvedDist) Bounds() (float64, float64) {
+	return d.lo, d.hi
+}
+
+func (d *DiscreteConvolvedDist) Step() float64 {
+	return d.step
+}
+
+// Mean returns the mean of the sum.
+func (d *DiscreteConvolvedDist) Mean() float64 {
+	mean := 0.0
+	for i, p := range d.pmf {
+		mean += p * float64(d.k0+i) * d.step
+	}
+	return mean
+}
@@ -0,0 +1,142 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package stats
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+)
+
+func TestConvolveNormal(t *testing.T) {
+	d := Convolve(NormalDist{1, 1}, NormalDist{2, 2})
+	want := NormalDist{3, math.Sqrt(5)}
+	for _, x := range []float64{-4, 0, 2, 3, 5.5, 10} {
+		if got := d.CDF(x); !aeqTol(got, want.CDF(x), 1e-5) {
+			t.Errorf("CDF(%v) = %v, want %v", x, got, want.CDF(x))
+		}
+		if got := d.PDF(x); !aeqTol(got, want.PDF(x), 1e-4) {
+			t.Errorf("PDF(%v) = %v, want %v", x, got, want.PDF(x))
+		}
+	}
+	for _, y := range []float64{0.01, 0.3, 0.5, 0.99} {
+		if got := d.InvCDF(y); !aeqTol(got, want.InvCDF(y), 1e-3) {
+			t.Errorf("InvCDF(%v) = %v, want %v", y, got, want.InvCDF(y))
+		}
+	}
+	l, h := d.Bounds()
+	wl, wh := want.Bounds()
+	if !aeqTol(l, wl, 1e-2) || !aeqTol(h, wh, 1e-2) {
+		t.Errorf("want bounds ~[%v, %v], got [%v, %v]", wl, wh, l, h)
+	}
+	if got := d.Mean(); !aeqTol(got, 3, 1e-6) {
+		t.Errorf("Mean() = %v, want 3", got)
+	}
+	testInvCDF(t, d, false)
+}
+
+func TestConvolveBounded(t *testing.T) {
+	// A (nearly) uniform distribution on [0, 1]. The sum of two
+	// is triangular on [0, 2].
+	unif := Truncate(LocScale(StdNormal, 0, 1e6), 0, 1)
+	d := Convolve(unif, unif)
+	if l, h := d.Bounds(); l != 0 || h != 2 {
+		t.Errorf("want bounds [0, 2], got [%v, %v]", l, h)
+	}
+	testFunc(t, "CDF", func(x float64) float64 {
+		return math.Round(d.CDF(x)*1e4) / 1e4
+	}, map[float64]float64{
+		-0.1: 0,
+		0:    0,
+		0.5:  0.125,
+		1:    0.5,
+		1.5:  0.875,
+		2:    1,
+		2.1:  1,
+	})
+	if got := d.PDF(0.5); !aeqTol(got, 0.5, 1e-3) {
+		t.Errorf("PDF(0.5) = %v, want 0.5", got)
+	}
+	if got := d.PDF(2.5); got != 0 {
+		t.Errorf("PDF(2.5) = %v, want 0", got)
+	}
+	r := rand.New(rand.NewSource(1))
+	for i := 0; i < 1000; i++ {
+		if x := d.Rand(r); x < 0 || x > 2 {
+			t.Fatalf("Rand() = %v, want in [0, 2]", x)
+		}
+	}
+}
+
+func TestConvolveExactBounds(t *testing.T) {
+	// The uniform distribution on [0, 1] has a nonzero density at
+	// both bounds, so its CDF must rise continuously from 0 and
+	// reach 1 exactly at the bounds, consistent with its PDF.
+	d := Convolve(BetaDist{1, 1})
+	eps := d.delta / 4
+	if got := d.CDF(0); got != 0 {
+		t.Errorf("CDF(0) = %v, want 0", got)
+	}
+	if got := d.CDF(1); got != 1 {
+		t.Errorf("CDF(1) = %v, want 1", got)
+	}
+	for _, x := range []float64{eps, 1 - eps} {
+		if got := d.PDF(x); !aeqTol(got, 1, 1e-9) {
+			t.Errorf("PDF(%v) = %v, want 1", x, got)
+		}
+	}
+	if got, want := d.CDF(eps), d.PDF(eps)*eps; !aeqTol(got, want, 1e-15) {
+		t.Errorf("CDF(%v) = %v, want %v", eps, got, want)
+	}
+	if got, want := d.CDF(1-eps), 1-d.PDF(1-eps)*eps; !aeqTol(got, want, 1e-12) {
+		t.Errorf("CDF(%v) = %v, want %v", 1-eps, got, want)
+	}
+	if got := d.InvCDF(d.CDF(eps)); !aeqTol(got, eps, 1e-12) {
+		t.Errorf("InvCDF(CDF(%v)) = %v", eps, got)
+	}
+	if got := d.Mean(); !aeqTol(got, 0.5, 1e-9) {
+		t.Errorf("Mean() = %v, want 0.5", got)
+	}
+	testInvCDF(t, d, true)
+}
+
+func TestConvolveDiscrete(t *testing.T) {
+	d := ConvolveDiscrete(BinomialDist{N: 3, P: 0.5}, BinomialDist{N: 5, P: 0.5})
+	want := BinomialDist{N: 8, P: 0.5}
+	testDiscreteCDF(t, "ConvolveDiscrete", d)
+	for x := -1.0; x <= 9; x += 0.5 {
+		if got := d.PMF(x); !aeq(got, want.PMF(x)) {
+			t.Errorf("PMF(%v) = %v, want %v", x, got, want.PMF(x))
+		}
+		if got := d.CDF(x); !aeq(got, want.CDF(x)) {
+			t.Errorf("CDF(%v) = %v, want %v", x, got, want.CDF(x))
+		}
+	}
+	if l, h := d.Bounds(); l != 0 || h != 8 {
+		t.Errorf("want bounds [0, 8], got [%v, %v]", l, h)
+	}
+	if got := d.Mean(); !aeq(got, 4) {
+		t.Errorf("Mean() = %v, want 4", got)
+	}
+	testFunc(t, "InvCDF", d.InvCDF, map[float64]float64{
+		-0.1: nan,
+		0:    0,
+		0.5:  4,
+		0.9:  6,
+		1:    8,
+	})
+	r := rand.New(rand.NewSource(1))
+	for i := 0; i < 1000; i++ {
+		if x := d.Rand(r); x != math.Floor(x) || x < 0 || x > 8 {
+			t.Fatalf("Rand() = %v", x)
+		}
+	}
+
+	// Shifted supports add.
+	s := ConvolveDiscrete(DiscreteMixture{[]float64{1}, []DiscreteDist{BinomialDist{N: 2, P: 0.5}}}, BinomialDist{N: 1, P: 0.5})
+	if l, h := s.Bounds(); l != 0 || h != 3 {
+		t.Errorf("want bounds [0, 3], got [%v, %v]", l, h)
+	}
+}
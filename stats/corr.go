@@ -0,0 +1,509 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package stats
+
+import (
+	"math"
+	"sort"
+)
+
+// BivariateSample is a collection of possibly weighted (x, y) pairs.
+type BivariateSample struct {
+	// Xs and Ys are the paired sample values. Xs and Ys must have
+	// the same length.
+	Xs, Ys []float64
+
+	// Weights[i] is the weight of the pair (Xs[i], Ys[i]). If
+	// Weights is nil, all pairs have weight 1. Weights must have
+	// the same length as Xs and all values must be non-negative.
+	//
+	// Weights are treated as frequency weights. For example, a
+	// pair with weight 2 is equivalent to two identical pairs
+	// with weight 1.
+	Weights []float64
+}
+
+// Weight returns the total weight of the BivariateSample.
+func (s BivariateSample) Weight() float64 {
+	return Sample{Xs: s.Xs, Weights: s.Weights}.Weight()
+}
+
+func (s BivariateSample) weight(i int) float64 {
+	if s.Weights == nil {
+		return 1
+	}
+	return s.Weights[i]
+}
+
+// A CorrelationTestResult is the result of a test for correlation
+// between two variables.
+type CorrelationTestResult struct {
+	// N is the size of the sample. For a weighted sample, this
+	// is the total weight rounded to the nearest integer.
+	N int
+
+	// Coefficient is the value of the correlation coefficient.
+	// This is in the range [-1, 1].
+	Coefficient float64
+
+	// AltHypothesis specifies the alternative hypothesis tested
+	// by this test against the null hypothesis that the variables
+	// are not correlated. LocationLess is the alternative
+	// hypothesis that the variables are negatively correlated and
+	// LocationGreater is the alternative hypothesis that they are
+	// positively correlated.
+	AltHypothesis LocationHypothesis
+
+	// P is the p-value of this test for the given null
+	// hypothesis.
+	P float64
+}
+
+// Pearson returns Pearson's product-moment correlation coefficient r
+// of s.
+//
+// If either variable has zero variance, this returns NaN.
+func (s BivariateSample) Pearson() float64 {
+	if len(s.Xs) != len(s.Ys) {
+		panic("len(Xs) != len(Ys)")
+	}
+
+	// This uses a bivariate, weighted form of Welford's online
+	// algorithm to compute the covariance and variances.
+	mx, my, wsum := 0.0, 0.0, 0.0
+	sxx, syy, sxy := 0.0, 0.0, 0.0
+	for i, x := range s.Xs {
+		w := s.weight(i)
+		if w == 0 {
+			continue
+		}
+		y := s.Ys[i]
+		wsum += w
+		dx, dy := x-mx, y-my
+		mx += dx * w / wsum
+		my += dy * w / wsum
+		sxx += w * dx * (x - mx)
+		syy += w * dy * (y - my)
+		sxy += w * dx * (y - my)
+	}
+	if sxx == 0 || syy == 0 {
+		return nan
+	}
+	r := sxy / math.Sqrt(sxx*syy)
+	// Clamp round-off error.
+	return math.Max(-1, math.Min(1, r))
+}
+
+// PearsonCI returns Pearson's correlation coefficient r of s and its
+// confidence interval, computed using the Fisher z-transformation.
+func (s BivariateSample) PearsonCI(confidence float64) (r, lo, hi float64) {
+	r = s.Pearson()
+	n := s.Weight()
+
+	if confidence <= 0 {
+		return r, r, r
+	} else if confidence >= 1 || n <= 3 {
+		// The sample is too small to have a CI.
+		return r, -1, 1
+	}
+
+	// z = arctanh(r) is approximately normally distributed with
+	// standard deviation 1/sqrt(n-3).
+	z := math.Atanh(r)
+	w := -StdNormal.InvCDF((1-confidence)/2) / math.Sqrt(n-3)
+	return r, math.Tanh(z - w), math.Tanh(z + w)
+}
+
+// PearsonTest tests the null hypothesis that the variables in s are
+// uncorrelated using Pearson's correlation coefficient. This test
+// assumes that the variables are bivariate normal. The p-value is
+// computed from the t-distribution with N-2 degrees of freedom.
+//
+// This can fail with ErrMismatchedSamples if len(s.Xs) != len(s.Ys),
+// ErrSampleSize if the sample has fewer than 3 values, or
+// ErrZeroVariance if either variable has zero variance.
+func PearsonTest(s BivariateSample, alt LocationHypothesis) (*CorrelationTestResult, error) {
+	if err := s.check(); err != nil {
+		return nil, err
+	}
+	r := s.Pearson()
+	if math.IsNaN(r) {
+		return nil, ErrZeroVariance
+	}
+	return newCorrelationTTestResult(r, s.Weight(), alt), nil
+}
+
+// newCorrelationTTestResult tests correlation coefficient r from a
+// sample of size n using the t-distribution approximation.
+func newCorrelationTTestResult(r, n float64, alt LocationHypothesis) *CorrelationTestResult {
+	var p float64
+	if r == 1 || r == -1 {
+		// The t-statistic is infinite.
+		switch alt {
+		case LocationDiffers:
+			p = 0
+		case LocationLess:
+			p = (1 + r) / 2
+		case LocationGreater:
+			p = (1 - r) / 2
+		}
+	} else {
+		dof := n - 2
+		t := r * math.Sqrt(dof/(1-r*r))
+		p = newTTestResult(0, 0, t, dof, alt).P
+	}
+	return &CorrelationTestResult{N: int(math.Round(n)), Coefficient: r, AltHypothesis: alt, P: p}
+}
+
+func (s BivariateSample) check() error {
+	if len(s.Xs) != len(s.Ys) {
+		return ErrMismatchedSamples
+	}
+	if s.Weight() < 3 {
+		return ErrSampleSize
+	}
+	return nil
+}
+
+// ranks returns the mid-ranks of xs, where each xs[i] has weight
+// weights[i]. If weights is nil, all weights are 1. Tied values are
+// assigned the average of the ranks they span.
+func ranks(xs, weights []float64) []float64 {
+	order := make([]int, len(xs))
+	for i := range order {
+		order[i] = i
+	}
+	sort.Slice(order, func(i, j int) bool { return xs[order[i]] < xs[order[j]] })
+
+	out := make([]float64, len(xs))
+	cum := 0.0
+	for i := 0; i < len(order); {
+		// Find the run of values tied with xs[order[i]].
+		j, w := i, 0.0
+		for ; j < len(order) && xs[order[j]] == xs[order[i]]; j++ {
+			if weights == nil {
+				w++
+			} else {
+				w += weights[order[j]]
+			}
+		}
+		rank := cum + (w+1)/2
+		for ; i < j; i++ {
+			out[order[i]] = rank
+		}
+		cum += w
+	}
+	return out
+}
+
+// Spearman returns Spearman's rank correlation coefficient ρ of s.
+// This is Pearson's correlation coefficient of the ranks of s.Xs and
+// s.Ys. Tied values are assigned their average rank.
+//
+// If either variable has only one distinct value, this returns NaN.
+func (s BivariateSample) Spearman() float64 {
+	if len(s.Xs) != len(s.Ys) {
+		panic("len(Xs) != len(Ys)")
+	}
+	return BivariateSample{
+		Xs:      ranks(s.Xs, s.Weights),
+		Ys:      ranks(s.Ys, s.Weights),
+		Weights: s.Weights,
+	}.Pearson()
+}
+
+// SpearmanTest tests the null hypothesis that the variables in s are
+// independent using Spearman's rank correlation coefficient. Unlike
+// PearsonTest, this test is non-parametric and detects any monotonic
+// relationship. The p-value is computed using the t-distribution
+// approximation with N-2 degrees of freedom.
+//
+// This can fail with ErrMismatchedSamples if len(s.Xs) != len(s.Ys),
+// ErrSampleSize if the sample has fewer than 3 values, or
+// ErrSamplesEqual if either variable has only one distinct value.
+func SpearmanTest(s BivariateSample, alt LocationHypothesis) (*CorrelationTestResult, error) {
+	if err := s.check(); err != nil {
+		return nil, err
+	}
+	rho := s.Spearman()
+	if math.IsNaN(rho) {
+		return nil, ErrSamplesEqual
+	}
+	return newCorrelationTTestResult(rho, s.Weight(), alt), nil
+}
+
+// kendallCounts holds the pair counts used to compute Kendall's τ.
+type kendallCounts struct {
+	// n0 is the total number of pairs.
+	n0 float64
+	// n1 and n2 are the number of pairs tied in x and y,
+	// respectively. n3 is the number of pairs tied in both.
+	n1, n2, n3 float64
+	// discordant is the number of discordant pairs.
+	discordant float64
+	// tiesX and tiesY are the weights of each group of tied
+	// values in x and y.
+	tiesX, tiesY []float64
+}
+
+// kendall computes the pair counts for Kendall's τ using Knight's
+// O(n log n) algorithm.
+//
+// Knight, William R. (1966). "A Computer Method for Calculating
+// Kendall's Tau with Ungrouped Data". Journal of the American
+// Statistical Association 61 (314): 436-439.
+func (s BivariateSample) kendall() kendallCounts {
+	type pair struct{ x, y, w float64 }
+	ps := make([]pair, 0, len(s.Xs))
+	wsum := 0.0
+	for i, x := range s.Xs {
+		if w := s.weight(i); w != 0 {
+			ps = append(ps, pair{x, s.Ys[i], w})
+			wsum += w
+		}
+	}
+
+	// pairs returns the number of pairs among w items.
+	pairs := func(w float64) float64 { return w * (w - 1) / 2 }
+
+	var c kendallCounts
+	c.n0 = pairs(wsum)
+
+	// Sort by x, then y, and count ties in x and joint ties.
+	sort.Slice(ps, func(i, j int) bool {
+		if ps[i].x != ps[j].x {
+			return ps[i].x < ps[j].x
+		}
+		return ps[i].y < ps[j].y
+	})
+	wx, wxy := ps[0].w, ps[0].w
+	for i := 1; i <= len(ps); i++ {
+		if i < len(ps) && ps[i].x == ps[i-1].x {
+			wx += ps[i].w
+			if ps[i].y == ps[i-1].y {
+				wxy += ps[i].w
+			} else {
+				c.n3 += pairs(wxy)
+				wxy = ps[i].w
+			}
+			continue
+		}
+		c.n1 += pairs(wx)
+		c.n3 += pairs(wxy)
+		c.tiesX = append(c.tiesX, wx)
+		if i < len(ps) {
+			wx, wxy = ps[i].w, ps[i].w
+		}
+	}
+
+	// Merge sort by y, counting the weight of pairs that must be
+	// swapped. Each such pair is discordant. Because ties in y
+	// are not swapped and ties in x are already ordered by y,
+	// this never counts tied pairs.
+	tmp := make([]pair, len(ps))
+	var msort func(ps, tmp []pair)
+	msort = func(ps, tmp []pair) {
+		if len(ps) <= 1 {
+			return
+		}
+		mid := len(ps) / 2
+		msort(ps[:mid], tmp[:mid])
+		msort(ps[mid:], tmp[mid:])
+
+		// Compute the total weight of the left half.
+		lw := 0.0
+		for _, p := range ps[:mid] {
+			lw += p.w
+		}
+		i, j, o := 0, mid, 0
+		for i < mid && j < len(ps) {
+			if ps[j].y < ps[i].y {
+				c.discordant += ps[j].w * lw
+				tmp[o] = ps[j]
+				j++
+			} else {
+				lw -= ps[i].w
+				tmp[o] = ps[i]
+				i++
+			}
+			o++
+		}
+		o += copy(tmp[o:], ps[i:mid])
+		copy(tmp[o:], ps[j:])
+		copy(ps, tmp)
+	}
+	msort(ps, tmp)
+
+	// ps is now sorted by y. Count ties in y.
+	wy := ps[0].w
+	for i := 1; i <= len(ps); i++ {
+		if i < len(ps) && ps[i].y == ps[i-1].y {
+			wy += ps[i].w
+			continue
+		}
+		c.n2 += pairs(wy)
+		c.tiesY = append(c.tiesY, wy)
+		if i < len(ps) {
+			wy = ps[i].w
+		}
+	}
+
+	return c
+}
+
+// s returns Kendall's S statistic, which is the number of concordant
+// pairs minus the number of discordant pairs.
+func (c *kendallCounts) s() float64 {
+	concordant := c.n0 - c.n1 - c.n2 + c.n3 - c.discordant
+	return concordant - c.discordant
+}
+
+// tauB returns Kendall's τ_b.
+func (c *kendallCounts) tauB() float64 {
+	denom := math.Sqrt((c.n0 - c.n1) * (c.n0 - c.n2))
+	if denom == 0 {
+		return nan
+	}
+	return math.Max(-1, math.Min(1, c.s()/denom))
+}
+
+// KendallTau returns Kendall's rank correlation coefficient τ_b of s.
+// τ_b adjusts for ties in either variable. If there are no ties, it
+// is equal to τ_a, the number of concordant pairs minus the number of
+// discordant pairs, divided by the total number of pairs.
+//
+// This runs in O(n log n) time.
+//
+// If either variable has only one distinct value, this returns NaN.
+func (s BivariateSample) KendallTau() float64 {
+	if len(s.Xs) != len(s.Ys) {
+		panic("len(Xs) != len(Ys)")
+	}
+	if s.Weight() == 0 {
+		return nan
+	}
+	c := s.kendall()
+	return c.tauB()
+}
+
+// KendallExactLimit gives the largest sample size for which the
+// exact distribution of Kendall's τ will be used by KendallTest.
+var KendallExactLimit = 50
+
+// KendallTest tests the null hypothesis that the variables in s are
+// independent using Kendall's τ_b rank correlation coefficient.
+//
+// If s is unweighted, has no ties, and has at most KendallExactLimit
+// values, the p-value is computed from the exact permutation
+// distribution of τ. Otherwise, this uses a normal approximation to
+// Kendall's S statistic with a variance corrected for ties [1].
+//
+// This can fail with ErrMismatchedSamples if len(s.Xs) != len(s.Ys),
+// ErrSampleSize if the sample has fewer than 3 values, or
+// ErrSamplesEqual if either variable has only one distinct value.
+//
+// [1] Kendall, M. G. (1970). Rank Correlation Methods, 4th edition.
+// Charles Griffin & Co.
+func KendallTest(s BivariateSample, alt LocationHypothesis) (*CorrelationTestResult, error) {
+	if err := s.check(); err != nil {
+		return nil, err
+	}
+	c := s.kendall()
+	tau := c.tauB()
+	if math.IsNaN(tau) {
+		return nil, ErrSamplesEqual
+	}
+	n := s.Weight()
+
+	var p float64
+	if s.Weights == nil && c.n1 == 0 && c.n2 == 0 && len(s.Xs) <= KendallExactLimit {
+		// Use the exact distribution of the number of
+		// discordant pairs, which is the distribution of the
+		// number of inversions of a random permutation.
+		cdf := kendallDiscordantCDF(len(s.Xs))
+		d := int(math.Round(c.discordant))
+		nPairs := len(cdf) - 1
+		// The distribution is symmetric about nPairs/2, so
+		// Pr[D >= d] = Pr[D <= nPairs-d].
+		pLess := cdf[nPairs-d] // Pr[D >= d]
+		pGreater := cdf[d]     // Pr[D <= d]
+		switch alt {
+		case LocationDiffers:
+			if 2*d == nPairs {
+				p = 1
+			} else {
+				p = 2 * math.Min(pLess, pGreater)
+			}
+		case LocationLess:
+			p = pLess
+		case LocationGreater:
+			p = pGreater
+		}
+	} else {
+		// Use the normal approximation of S with tie
+		// correction.
+		v0 := n * (n - 1) * (2*n + 5)
+		var vt, vu, t1, u1, t2, u2 float64
+		for _, t := range c.tiesX {
+			vt += t * (t - 1) * (2*t + 5)
+			t1 += t * (t - 1)
+			t2 += t * (t - 1) * (t - 2)
+		}
+		for _, u := range c.tiesY {
+			vu += u * (u - 1) * (2*u + 5)
+			u1 += u * (u - 1)
+			u2 += u * (u - 1) * (u - 2)
+		}
+		v := (v0-vt-vu)/18 +
+			t1*u1/(2*n*(n-1)) +
+			t2*u2/(9*n*(n-1)*(n-2))
+		z := c.s() / math.Sqrt(v)
+		switch alt {
+		case LocationDiffers:
+			p = 2 * math.Min(StdNormal.CDF(z), 1-StdNormal.CDF(z))
+		case LocationLess:
+			p = StdNormal.CDF(z)
+		case LocationGreater:
+			p = 1 - StdNormal.CDF(z)
+		}
+	}
+
+	return &CorrelationTestResult{N: int(math.Round(n)), Coefficient: tau, AltHypothesis: alt, P: p}, nil
+}
+
+// kendallDiscordantCDF returns the CDF of the number of discordant
+// pairs in a sample of n pairs with no ties under the null
+// hypothesis. cdf[d] is Pr[D <= d] for 0 <= d <= n(n-1)/2.
+func kendallDiscordantCDF(n int) []float64 {
+	// The number of discordant pairs is the number of inversions
+	// of a uniformly random permutation. The generating function
+	// of this distribution is
+	//
+	//   ∏_{k=1}^{n} (1 + q + ... + q^(k-1)) / k
+	//
+	// We expand this product one factor at a time, using a
+	// running sum to compute each convolution in linear time.
+	nPairs := n * (n - 1) / 2
+	pmf := make([]float64, nPairs+1)
+	next := make([]float64, nPairs+1)
+	pmf[0] = 1
+	deg := 0
+	for k := 2; k <= n; k++ {
+		deg += k - 1
+		sum := 0.0
+		for d := 0; d <= deg; d++ {
+			sum += pmf[d]
+			if d-k >= 0 {
+				sum -= pmf[d-k]
+			}
+			next[d] = sum / float64(k)
+		}
+		pmf, next = next, pmf
+	}
+	for d := 1; d <= nPairs; d++ {
+		pmf[d] += pmf[d-1]
+	}
+	return pmf
+}
@@ -0,0 +1,160 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package stats
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+)
+
+func TestPearson(t *testing.T) {
+	s := BivariateSample{Xs: []float64{1, 2, 3, 4, 5}, Ys: []float64{2, 1, 4, 3, 5}}
+	if r := s.Pearson(); !aeq(0.8, r) {
+		t.Errorf("want r=0.8, got %v", r)
+	}
+
+	r, lo, hi := s.PearsonCI(0.95)
+	if !aeq(0.8, r) || !aeq(-0.2796400419693546, lo) || !aeq(0.9861961933012714, hi) {
+		t.Errorf("want 0.8 [-0.27964, 0.98620], got %v [%v, %v]", r, lo, hi)
+	}
+	res, err := PearsonTest(s, LocationDiffers)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if res.N != 5 || !aeq(0.1040880386618277, res.P) {
+		t.Errorf("want p=0.1040880, got %+v", res)
+	}
+
+	// Weights are frequency weights.
+	sw := BivariateSample{Xs: []float64{1, 2, 3}, Ys: []float64{1, 3, 2}, Weights: []float64{2, 1, 3}}
+	su := BivariateSample{Xs: []float64{1, 1, 2, 3, 3, 3}, Ys: []float64{1, 1, 3, 2, 2, 2}}
+	if want, got := su.Pearson(), sw.Pearson(); !aeq(want, got) {
+		t.Errorf("want weighted r=%v, got %v", want, got)
+	}
+
+	if _, err := PearsonTest(BivariateSample{Xs: []float64{1, 2, 3}, Ys: []float64{1, 1, 1}}, LocationDiffers); err != ErrZeroVariance {
+		t.Errorf("want ErrZeroVariance, got %v", err)
+	}
+	if _, err := PearsonTest(BivariateSample{Xs: []float64{1, 2}, Ys: []float64{1, 2}}, LocationDiffers); err != ErrSampleSize {
+		t.Errorf("want ErrSampleSize, got %v", err)
+	}
+	if _, err := PearsonTest(BivariateSample{Xs: []float64{1, 2, 3}, Ys: []float64{1, 2}}, LocationDiffers); err != ErrMismatchedSamples {
+		t.Errorf("want ErrMismatchedSamples, got %v", err)
+	}
+}
+
+func TestSpearman(t *testing.T) {
+	s := BivariateSample{Xs: []float64{10, 20, 30, 40, 50}, Ys: []float64{4, 1, 16, 9, 25}}
+	if rho := s.Spearman(); !aeq(0.8, rho) {
+		t.Errorf("want ρ=0.8, got %v", rho)
+	}
+
+	// With ties.
+	s = BivariateSample{Xs: []float64{1, 2, 2, 3}, Ys: []float64{1, 2, 3, 3}}
+	want := BivariateSample{Xs: []float64{1, 2.5, 2.5, 4}, Ys: []float64{1, 2, 3.5, 3.5}}.Pearson()
+	if rho := s.Spearman(); !aeq(want, rho) {
+		t.Errorf("want ρ=%v, got %v", want, rho)
+	}
+
+	// Weighted ranks.
+	sw := BivariateSample{Xs: []float64{1, 2, 3}, Ys: []float64{1, 3, 2}, Weights: []float64{2, 1, 3}}
+	su := BivariateSample{Xs: []float64{1, 1, 2, 3, 3, 3}, Ys: []float64{1, 1, 3, 2, 2, 2}}
+	if want, got := su.Spearman(), sw.Spearman(); !aeq(want, got) {
+		t.Errorf("want weighted ρ=%v, got %v", want, got)
+	}
+}
+
+// kendallSlow computes τ_b in O(n²) time directly from its
+// definition.
+func kendallSlow(s BivariateSample) float64 {
+	var c, d, tx, ty float64
+	var xs, ys, ws []float64
+	for i := range s.Xs {
+		// Expand integer frequency weights.
+		for k := 0; k < int(s.weight(i)); k++ {
+			xs, ys, ws = append(xs, s.Xs[i]), append(ys, s.Ys[i]), append(ws, 1)
+		}
+	}
+	for i := range xs {
+		for j := i + 1; j < len(xs); j++ {
+			dx, dy := xs[i]-xs[j], ys[i]-ys[j]
+			switch {
+			case dx == 0 && dy == 0:
+			case dx == 0:
+				tx++
+			case dy == 0:
+				ty++
+			case dx*dy > 0:
+				c++
+			default:
+				d++
+			}
+		}
+	}
+	return (c - d) / math.Sqrt((c+d+tx)*(c+d+ty))
+}
+
+func TestKendallTau(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	for iter := 0; iter < 100; iter++ {
+		n := 2 + r.Intn(30)
+		s := BivariateSample{Xs: make([]float64, n), Ys: make([]float64, n)}
+		if iter%2 == 1 {
+			s.Weights = make([]float64, n)
+		}
+		for i := range s.Xs {
+			// Use a small range to get lots of ties.
+			s.Xs[i] = float64(r.Intn(8))
+			s.Ys[i] = float64(r.Intn(8))
+			if s.Weights != nil {
+				s.Weights[i] = float64(r.Intn(3))
+			}
+		}
+		want, got := kendallSlow(s), s.KendallTau()
+		if !(math.IsNaN(want) && math.IsNaN(got)) && !aeq(want, got) {
+			t.Errorf("for %+v: want τ=%v, got %v", s, want, got)
+		}
+	}
+}
+
+func TestKendallTest(t *testing.T) {
+	s := BivariateSample{Xs: []float64{1, 2, 3, 4, 5}, Ys: []float64{2, 1, 4, 3, 5}}
+	check := func(s BivariateSample, alt LocationHypothesis, wtau, wp float64) {
+		t.Helper()
+		res, err := KendallTest(s, alt)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !aeq(wtau, res.Coefficient) || !aeq(wp, res.P) || res.AltHypothesis != alt {
+			t.Errorf("want τ=%v p=%v, got %+v", wtau, wp, res)
+		}
+	}
+	// Exact distribution: 14 of the 120 permutations of 5
+	// elements have at most 2 inversions.
+	check(s, LocationGreater, 0.6, 14.0/120)
+	check(s, LocationDiffers, 0.6, 28.0/120)
+	check(s, LocationLess, 0.6, 1-5.0/120)
+
+	// Normal approximation with ties. S=9 and Var(S)=26.4.
+	s = BivariateSample{Xs: []float64{1, 2, 2, 3, 4, 5}, Ys: []float64{1, 3, 2, 2, 5, 4}}
+	check(s, LocationDiffers, 9/math.Sqrt(14*14), 0.07983871964585254)
+
+	// The exact and approximate distributions should agree for
+	// moderately large samples.
+	defer func(old int) { KendallExactLimit = old }(KendallExactLimit)
+	r := rand.New(rand.NewSource(1))
+	s = BivariateSample{Xs: make([]float64, 40), Ys: make([]float64, 40)}
+	for i := range s.Xs {
+		s.Xs[i] = float64(i)
+		s.Ys[i] = float64(i) + 100*r.Float64()
+	}
+	exact, _ := KendallTest(s, LocationDiffers)
+	KendallExactLimit = 0
+	approx, _ := KendallTest(s, LocationDiffers)
+	if math.Abs(exact.P-approx.P) > 0.05*exact.P {
+		t.Errorf("exact p=%v differs from approximate p=%v", exact.P, approx.P)
+	}
+}
@@ -0,0 +1,286 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package stats
+
+import (
+	"math"
+	"math/rand"
+	"sort"
+)
+
+// A DipTestResult is the result of Hartigan's dip test.
+type DipTestResult struct {
+	// N is the size of the sample.
+	N int
+
+	// Dip is the value of the dip statistic. This is the maximum
+	// distance between the empirical CDF of the sample and the
+	// unimodal CDF that minimizes this maximum distance. It is
+	// always in the range [1/(2N), 1/4].
+	Dip float64
+
+	// ModalLo and ModalHi are the bounds of the modal interval
+	// of the closest unimodal CDF.
+	ModalLo, ModalHi float64
+
+	// P is the p-value of the dip test for the null hypothesis
+	// that the sample was drawn from a unimodal distribution.
+	P float64
+}
+
+// DipTestReplicates is the number of samples drawn from the uniform
+// distribution by DipTest to simulate the null distribution of the
+// dip statistic.
+//
+// The standard error of the computed p-value is at most
+// 1/(2*sqrt(DipTestReplicates)).
+var DipTestReplicates = 2000
+
+// Dip returns Hartigan's dip statistic of xs, which is the maximum
+// distance between the empirical CDF of xs and the closest unimodal
+// CDF, along with the bounds of the modal interval of that unimodal
+// CDF.
+//
+// If len(xs) == 0, Dip returns NaN for all results.
+//
+// See DipTest for details.
+func Dip(xs []float64) (dip, modalLo, modalHi float64) {
+	if len(xs) == 0 {
+		return nan, nan, nan
+	}
+	if !sort.Float64sAreSorted(xs) {
+		xs = append([]float64(nil), xs...)
+		sort.Float64s(xs)
+	}
+	return dipSorted(xs)
+}
+
+// dipSorted computes the dip statistic of the sorted sample xs.
+//
+// This is a translation of the algorithm from Hartigan, P. M. (1985).
+// "Algorithm AS 217: Computation of the Dip Statistic to Test for
+// Unimodality". Journal of the Royal Statistical Society C 34 (3):
+// 320-325, incorporating the fixes made in the R diptest package.
+func dipSorted(xs []float64) (dip, modalLo, modalHi float64) {
+	n := len(xs)
+
+	// The algorithm is much easier to follow with 1-based
+	// indexes, so we follow the original and offset everything
+	// by one.
+	x := make([]float64, n+1)
+	copy(x[1:], xs)
+
+	// To save divisions, we work in units of 2n*dip until the
+	// end. The minimum possible dip is 1/(2n).
+	low, high := 1, n
+	dip = 1
+	if n < 2 || x[n] == x[1] {
+		return dip / float64(2*n), x[low], x[high]
+	}
+
+	// Compute the indexes mn[1..n] over which combination is
+	// necessary for the greatest convex minorant (GCM) fit.
+	mn := make([]int, n+1)
+	mn[1] = 1
+	for j := 2; j <= n; j++ {
+		mn[j] = j - 1
+		for {
+			mnj := mn[j]
+			mnmnj := mn[mnj]
+			if mnj == 1 || (x[j]-x[mnj])*float64(mnj-mnmnj) < (x[mnj]-x[mnmnj])*float64(j-mnj) {
+				break
+			}
+			mn[j] = mnmnj
+		}
+	}
+
+	// Compute the indexes mj[1..n] over which combination is
+	// necessary for the least concave majorant (LCM) fit.
+	mj := make([]int, n+1)
+	mj[n] = n
+	for k := n - 1; k >= 1; k-- {
+		mj[k] = k + 1
+		for {
+			mjk := mj[k]
+			mjmjk := mj[mjk]
+			if mjk == n || (x[k]-x[mjk])*float64(mjk-mjmjk) < (x[mjk]-x[mjmjk])*float64(k-mjk) {
+				break
+			}
+			mj[k] = mjmjk
+		}
+	}
+
+	gcm := make([]int, n+1)
+	lcm := make([]int, n+1)
+	for {
+		// Collect the change points of the GCM from high to
+		// low.
+		var i int
+		gcm[1] = high
+		for i = 1; gcm[i] > low; i++ {
+			gcm[i+1] = mn[gcm[i]]
+		}
+		ig, lgcm := i, i
+		ix := ig - 1
+
+		// Collect the change points of the LCM from low to
+		// high.
+		lcm[1] = low
+		for i = 1; lcm[i] < high; i++ {
+			lcm[i+1] = mj[lcm[i]]
+		}
+		ih, llcm := i, i
+		iv := 2
+
+		// Find the largest distance greater than dip between
+		// the GCM and the LCM from low to high.
+		d := 0.0
+		if lgcm != 2 || llcm != 2 {
+			for {
+				gcmix, lcmiv := gcm[ix], lcm[iv]
+				if gcmix > lcmiv {
+					// The next point of either the GCM
+					// or the LCM is from the LCM.
+					gcmi1 := gcm[ix+1]
+					dx := float64(lcmiv-gcmi1+1) -
+						(x[lcmiv]-x[gcmi1])*float64(gcmix-gcmi1)/(x[gcmix]-x[gcmi1])
+					iv++
+					if dx >= d {
+						d, ig, ih = dx, ix+1, iv-1
+					}
+				} else {
+					// The next point of either the GCM
+					// or the LCM is from the GCM.
+					lcmiv1 := lcm[iv-1]
+					dx := (x[gcmix]-x[lcmiv1])*float64(lcmiv-lcmiv1)/(x[lcmiv]-x[lcmiv1]) -
+						float64(gcmix-lcmiv1-1)
+					ix--
+					if dx >= d {
+						d, ig, ih = dx, ix+1, iv
+					}
+				}
+				if ix < 1 {
+					ix = 1
+				}
+				if iv > llcm {
+					iv = llcm
+				}
+				if gcm[ix] == lcm[iv] {
+					break
+				}
+			}
+		} else {
+			d = 1
+		}
+
+		if d < dip {
+			break
+		}
+
+		// Compute the dip of the convex minorant for the
+		// current low and high.
+		dipL := 0.0
+		for j := ig; j < lgcm; j++ {
+			maxT := 1.0
+			jk, jk1 := gcm[j], gcm[j+1]
+			if jk-jk1 > 1 && x[jk] != x[jk1] {
+				c := float64(jk-jk1) / (x[jk] - x[jk1])
+				for jj := jk1; jj <= jk; jj++ {
+					t := float64(jj-jk1+1) - (x[jj]-x[jk1])*c
+					maxT = math.Max(maxT, t)
+				}
+			}
+			dipL = math.Max(dipL, maxT)
+		}
+
+		// Compute the dip of the concave majorant.
+		dipU := 0.0
+		for j := ih; j < llcm; j++ {
+			maxT := 1.0
+			jk, jk1 := lcm[j], lcm[j+1]
+			if jk1-jk > 1 && x[jk1] != x[jk] {
+				c := float64(jk1-jk) / (x[jk1] - x[jk])
+				for jj := jk; jj <= jk1; jj++ {
+					t := (x[jj]-x[jk])*c - float64(jj-jk-1)
+					maxT = math.Max(maxT, t)
+				}
+			}
+			dipU = math.Max(dipU, maxT)
+		}
+
+		dip = math.Max(dip, math.Max(dipL, dipU))
+
+		// Without this check, this may loop forever (as
+		// noted by Martin Maechler in the R diptest package).
+		if low == gcm[ig] && high == lcm[ih] {
+			break
+		}
+		low, high = gcm[ig], lcm[ih]
+	}
+
+	return dip / float64(2*n), x[low], x[high]
+}
+
+// DipTest performs Hartigan's dip test [1] of the null hypothesis
+// that xs was drawn from a unimodal distribution against the
+// alternative hypothesis that it was drawn from a multimodal
+// distribution.
+//
+// The dip statistic is the maximum distance between the empirical
+// CDF of xs and the unimodal CDF that minimizes that maximum
+// distance. The p-value is computed against the uniform
+// distribution, which is the "least favorable" unimodal
+// distribution (its dip converges to 0 most slowly). DipTest
+// simulates the distribution of the dip statistic for a uniform
+// sample of size len(xs) using DipTestReplicates samples drawn from
+// r. If r is nil, it uses the default global source. The p-value is
+// (count+1)/(DipTestReplicates+1), where count is the number of
+// simulated dips at least as large as the dip of xs, so it is never
+// 0.
+//
+// This can fail with ErrSampleSize if xs has fewer than 3 values or
+// ErrSamplesEqual if all values of xs are equal.
+//
+// [1] Hartigan, J. A.; Hartigan, P. M. (1985). "The Dip Test of
+// Unimodality". Annals of Statistics 13 (1): 70-84.
+func DipTest(xs []float64, r *rand.Rand) (*DipTestResult, error) {
+	n := len(xs)
+	if n < 3 {
+		return nil, ErrSampleSize
+	}
+	xs = append([]float64(nil), xs...)
+	sort.Float64s(xs)
+	if xs[0] == xs[n-1] {
+		return nil, ErrSamplesEqual
+	}
+
+	dip, lo, hi := dipSorted(xs)
+
+	// Simulate the null distribution. The dip is invariant under
+	// affine transformations, so any uniform distribution
+	// suffices. Generate sorted uniform samples directly from
+	// cumulative sums of exponential spacings. Normally these
+	// would be divided by one more spacing to scale them to [0,
+	// 1], but since the dip is scale invariant, we skip this.
+	exp := rand.ExpFloat64
+	if r != nil {
+		exp = r.ExpFloat64
+	}
+	us := make([]float64, n)
+	count := 0
+	for rep := 0; rep < DipTestReplicates; rep++ {
+		sum := 0.0
+		for i := range us {
+			sum += exp()
+			us[i] = sum
+		}
+		if udip, _, _ := dipSorted(us); udip >= dip {
+			count++
+		}
+	}
+	p := float64(count+1) / float64(DipTestReplicates+1)
+
+	return &DipTestResult{N: n, Dip: dip, ModalLo: lo, ModalHi: hi, P: p}, nil
+}
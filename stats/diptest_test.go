@@ -0,0 +1,73 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package stats
+
+import (
+	"math/rand"
+	"testing"
+)
+
+func TestDip(t *testing.T) {
+	check := func(xs []float64, wdip, wlo, whi float64) {
+		t.Helper()
+		dip, lo, hi := Dip(xs)
+		if !aeq(wdip, dip) || lo != wlo || hi != whi {
+			t.Errorf("Dip(%v): want %v [%v,%v], got %v [%v,%v]", xs, wdip, wlo, whi, dip, lo, hi)
+		}
+	}
+
+	// Evenly spaced samples have the minimum dip of 1/(2n).
+	check([]float64{1, 2, 3, 4}, 1.0/8, 1, 4)
+	check([]float64{4, 3, 2, 1}, 1.0/8, 1, 4)
+	check([]float64{1, 1, 1}, 1.0/6, 1, 1)
+
+	check([]float64{1, 2, 3, 4, 10, 11, 12, 13}, 1.0/6, 1, 4)
+
+	// Two well-separated clusters approach the maximum dip of
+	// 1/4.
+	var xs []float64
+	for i := 0; i < 50; i++ {
+		xs = append(xs, float64(i)/100, 10+float64(i)/100)
+	}
+	check(xs, 0.23775, 10, 10.49)
+}
+
+func TestDipTest(t *testing.T) {
+	defer func(old int) { DipTestReplicates = old }(DipTestReplicates)
+	DipTestReplicates = 500
+
+	r := rand.New(rand.NewSource(1))
+	var uni, bi []float64
+	for i := 0; i < 100; i++ {
+		uni = append(uni, r.NormFloat64())
+		bi = append(bi, r.NormFloat64(), r.NormFloat64()+4)
+	}
+
+	res, err := DipTest(uni, r)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if res.N != 100 || res.P < 0.5 {
+		t.Errorf("want unimodal result, got %+v", res)
+	}
+
+	res, err = DipTest(bi, r)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if res.N != 200 || res.P > 0.01 {
+		t.Errorf("want multimodal result, got %+v", res)
+	}
+	if min := 1 / float64(DipTestReplicates+1); res.P < min {
+		t.Errorf("want p-value at least %v, got %v", min, res.P)
+	}
+
+	if _, err := DipTest([]float64{1, 2}, r); err != ErrSampleSize {
+		t.Errorf("want ErrSampleSize, got %v", err)
+	}
+	if _, err := DipTest([]float64{1, 1, 1}, r); err != ErrSamplesEqual {
+		t.Errorf("want ErrSamplesEqual, got %v", err)
+	}
+}
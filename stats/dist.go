@@ -4,7 +4,10 @@
 
 package stats
 
-import "math/rand"
+import (
+	"math"
+	"math/rand"
+)
 
 // A DistCommon is a statistical distribution. DistCommon is a base
 // interface provided by both continuous and discrete distributions.
@@ -102,10 +105,13 @@ type DiscreteDist interface {
 // If y < 0 or y > 1, f returns NaN.
 //
 // If dist implements InvCDF(float64) float64, this returns that
-// method. Otherwise, it returns a function that uses a generic
-// numerical method to construct the inverse CDF at y by finding x
-// such that dist.CDF(x) == y. This may have poor precision around
-// points of discontinuity, including f(0) and f(1).
+// method. If dist is a DiscreteDist, it returns a function that
+// searches the points of dist for the smallest x such that
+// dist.CDF(x) >= y, so f always returns a multiple of dist.Step().
+// Otherwise, it returns a function that uses a generic numerical
+// method to construct the inverse CDF at y by finding x such that
+// dist.CDF(x) == y. This may have poor precision around points of
+// discontinuity, including f(0) and f(1).
 func InvCDF(dist DistCommon) func(y float64) (x float64) {
 	type invCDF interface {
 		InvCDF(float64) float64
@@ -113,11 +119,11 @@ func InvCDF(dist DistCommon) func(y float64) (x float64) {
 	if dist, ok := dist.(invCDF); ok {
 		return dist.InvCDF
 	}
+	if dist, ok := dist.(DiscreteDist); ok {
+		return discreteInvCDF(dist)
+	}
 
 	// Otherwise, use a numerical algorithm.
-	//
-	// TODO: For discrete distributions, use the step size to
-	// inform this computation.
 	return func(y float64) (x float64) {
 		const almostInf = 1e100
 		const xtol = 1e-16
@@ -185,7 +191,10 @@ func InvCDF(dist DistCommon) func(y float64) (x float64) {
 // If dist implements Rand(*rand.Rand) float64, Rand returns that
 // method. Otherwise, it returns a generic generator based on dist's
 // inverse CDF (which may in turn use an efficient implementation or a
-// generic numerical implementation; see InvCDF).
+// generic numerical implementation; see InvCDF). For a DiscreteDist,
+// the generic generator returns only points of dist with non-zero
+// probability. To sample repeatedly from a discrete distribution
+// with finite support, NewAliasSampler is usually faster.
 func Rand(dist DistCommon) func(*rand.Rand) float64 {
 	type distRand interface {
 		Rand(*rand.Rand) float64
@@ -208,3 +217,135 @@ func Rand(dist DistCommon) func(*rand.Rand) float64 {
 		return inv(y)
 	}
 }
+
+// DistMean returns the mean of dist.
+//
+// If dist implements Mean() float64, this returns that method's
+// result. Otherwise, if dist is a DiscreteDist, it sums over the
+// points of dist, and if dist is a Dist, it numerically integrates
+// its PDF. In both cases, tails with negligible probability are
+// ignored, so the result is not meaningful for distributions whose
+// mean is undefined or infinite. If dist is neither, this returns
+// NaN.
+func DistMean(dist DistCommon) float64 {
+	if dist, ok := dist.(interface{ Mean() float64 }); ok {
+		return dist.Mean()
+	}
+	return distMoment(dist, func(x float64) float64 { return x })
+}
+
+// DistVariance returns the variance of dist.
+//
+// If dist implements Variance() float64, this returns that method's
+// result. Otherwise, it computes the variance by summation or
+// numerical integration around DistMean(dist), like DistMean.
+func DistVariance(dist DistCommon) float64 {
+	if dist, ok := dist.(interface{ Variance() float64 }); ok {
+		return dist.Variance()
+	}
+	mean := DistMean(dist)
+	return distMoment(dist, func(x float64) float64 {
+		return (x - mean) * (x - mean)
+	})
+}
+
+// distMoment returns the expected value of f(X), where X is
+// distributed according to dist.
+func distMoment(dist DistCommon, f func(x float64) float64) float64 {
+	switch d := dist.(type) {
+	case DiscreteDist:
+		return sumPoints(func(x float64) float64 {
+			return f(x) * d.PMF(x)
+		}, d)
+	case Dist:
+		return integrateDists(func(x float64) float64 {
+			if p := d.PDF(x); p != 0 {
+				return f(x) * p
+			}
+			return 0
+		}, d)
+	}
+	return nan
+}
+
+// discreteInvCDF returns the inverse CDF function of dist, which
+// searches over the multiples of dist.Step().
+func discreteInvCDF(dist DiscreteDist) func(y float64) float64 {
+	step := dist.Step()
+	return func(y float64) float64 {
+		l, h := dist.Bounds()
+		if y < 0 || y > 1 || math.IsNaN(y) {
+			return nan
+		} else if y == 0 {
+			if dist.CDF(l-step) == 0 {
+				// Finite support
+				return l
+			}
+			return -inf
+		} else if y == 1 && dist.CDF(h) != 1 {
+			// Infinite support
+			return inf
+		}
+
+		// Find points loK, hiK for which
+		// CDF(loK*step) < y <= CDF(hiK*step). Points are
+		// indexed by float64 so this can't overflow.
+		cdf := func(k float64) float64 { return dist.CDF(k * step) }
+		loK, hiK := math.Round(l/step)-1, math.Round(h/step)
+		for delta := 1.0; cdf(loK) >= y; delta *= 2 {
+			if math.IsInf(loK, 0) {
+				return -inf
+			}
+			hiK, loK = loK, loK-delta
+		}
+		for delta := 1.0; cdf(hiK) < y; delta *= 2 {
+			if math.IsInf(hiK, 0) {
+				return inf
+			}
+			loK, hiK = hiK, hiK+delta
+		}
+
+		// Bisect to find the smallest such hiK.
+		for hiK-loK > 1 {
+			mid := math.Floor(loK + (hiK-loK)/2)
+			if cdf(mid) < y {
+				loK = mid
+			} else {
+				hiK = mid
+			}
+		}
+		return hiK * step
+	}
+}
+
+// tailBounds returns bounds for dist that exclude at most tail
+// probability in each tail. loExact and hiExact indicate that the
+// corresponding bound from dist.Bounds is exact, in which case it is
+// returned unchanged.
+func tailBounds(dist DistCommon, tail float64) (lo, hi float64, loExact, hiExact bool) {
+	lo, hi = dist.Bounds()
+	if d, ok := dist.(DiscreteDist); ok {
+		step := d.Step()
+		loExact, hiExact = d.CDF(lo-step) == 0, d.CDF(hi) == 1
+		if !loExact {
+			for d.CDF(lo-step) > tail {
+				lo -= step
+			}
+		}
+		if !hiExact {
+			for 1-d.CDF(hi) > tail {
+				hi += step
+			}
+		}
+		return
+	}
+
+	loExact, hiExact = dist.CDF(lo) == 0, dist.CDF(hi) == 1
+	if !loExact {
+		lo = math.Min(lo, InvCDF(dist)(tail))
+	}
+	if !hiExact {
+		hi = math.Max(hi, InvCDF(dist)(1-tail))
+	}
+	return
+}
@@ -6,6 +6,8 @@ package stats
 
 import (
 	"fmt"
+	"math"
+	"math/rand"
 	"testing"
 )
 
@@ -46,3 +48,108 @@ func TestInvCDF(t *testing.T) {
 			})
 	}
 }
+
+// geometricDist is the distribution of the number of failures before
+// the first success in Bernoulli trials with success probability P.
+// It has infinite support and does not implement InvCDF, Mean, or
+// Variance.
+type geometricDist struct {
+	P float64
+}
+
+func (d geometricDist) PMF(x float64) float64 {
+	if x < 0 {
+		return 0
+	}
+	return math.Pow(1-d.P, math.Floor(x)) * d.P
+}
+
+func (d geometricDist) CDF(x float64) float64 {
+	if x < 0 {
+		return 0
+	}
+	return 1 - math.Pow(1-d.P, math.Floor(x)+1)
+}
+
+func (d geometricDist) Bounds() (float64, float64) {
+	return 0, math.Ceil(math.Log(0.001) / math.Log(1-d.P))
+}
+
+func (d geometricDist) Step() float64 {
+	return 1
+}
+
+func TestDiscreteInvCDF(t *testing.T) {
+	check := func(name string, dist DiscreteDist) {
+		t.Helper()
+		inv := InvCDF(dist)
+		l, h := dist.Bounds()
+		step := dist.Step()
+		for x := l; x <= h; x += step {
+			if dist.PMF(x) == 0 {
+				continue
+			}
+			// The smallest y that maps to x is just above
+			// CDF(x-step), and the largest is CDF(x).
+			for _, y := range []float64{dist.CDF(x-step) + 1e-9, dist.CDF(x)} {
+				if got := inv(y); got != x {
+					t.Errorf("InvCDF(%s)(%v) = %v, want %v", name, y, got, x)
+				}
+			}
+		}
+		if got := inv(0); got != l {
+			t.Errorf("InvCDF(%s)(0) = %v, want %v", name, got, l)
+		}
+		if got := inv(1); got != h {
+			t.Errorf("InvCDF(%s)(1) = %v, want %v", name, got, h)
+		}
+		if got := inv(1.5); !math.IsNaN(got) {
+			t.Errorf("InvCDF(%s)(1.5) = %v, want NaN", name, got)
+		}
+	}
+	check("BinomialDist", BinomialDist{N: 10, P: 0.3})
+	check("UDist", UDist{N1: 3, N2: 4, T: []int{2, 1, 1, 2, 1}})
+	check("DiscreteMixture", DiscreteMixture{[]float64{1, 1}, []DiscreteDist{BinomialDist{N: 4, P: 0.5}, BinomialDist{N: 8, P: 0.9}}})
+
+	// Quantiles beyond the bounds of an infinite distribution.
+	g := geometricDist{0.5}
+	testFunc(t, "InvCDF(geometricDist)", InvCDF(g), map[float64]float64{
+		0:               0,
+		0.5:             0,
+		0.75:            1,
+		1 - 1.0/(1<<20): 19,
+		1:               inf,
+	})
+
+	// Random values are points of the distribution with non-zero
+	// probability.
+	r := rand.New(rand.NewSource(1))
+	u := UDist{N1: 3, N2: 4, T: []int{2, 1, 1, 2, 1}}
+	for i := 0; i < 100; i++ {
+		if x := Rand(u)(r); x != math.Floor(x*2)/2 || u.PMF(x) == 0 {
+			t.Fatalf("Rand(UDist) returned %v", x)
+		}
+	}
+}
+
+func TestDistMoments(t *testing.T) {
+	// funnyCDF has neither a PDF nor a PMF.
+	for _, test := range []struct {
+		dist           DistCommon
+		mean, variance float64
+	}{
+		{BinomialDist{N: 10, P: 0.3}, 3, 2.1},
+		{UDist{N1: 3, N2: 4}, 6, 8},
+		{geometricDist{0.25}, 3, 12},
+		{LocScale(StdNormal, 1, 2), 1, 4},
+		{Truncate(StdNormal, 0, inf), math.Sqrt(2 / math.Pi), 1 - 2/math.Pi},
+		{funnyCDF{0}, nan, nan},
+	} {
+		if got := DistMean(test.dist); !aeqTol(got, test.mean, 1e-8) && !(math.IsNaN(got) && math.IsNaN(test.mean)) {
+			t.Errorf("DistMean(%v) = %v, want %v", test.dist, got, test.mean)
+		}
+		if got := DistVariance(test.dist); !aeqTol(got, test.variance, 1e-8) && !(math.IsNaN(got) && math.IsNaN(test.variance)) {
+			t.Errorf("DistVariance(%v) = %v, want %v", test.dist, got, test.variance)
+		}
+	}
+}
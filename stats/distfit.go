@@ -0,0 +1,212 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package stats
+
+import (
+	"math"
+
+	"github.com/aclements/go-moremath/mathx"
+)
+
+// A FitResult is the result of fitting a parametric distribution to
+// a Sample.
+type FitResult struct {
+	// Dist is the fitted distribution.
+	Dist DistCommon
+
+	// Params are the fitted parameters of Dist. The order of the
+	// parameters is documented by each fitting function.
+	Params []float64
+
+	// StdErrs are the asymptotic standard errors of Params,
+	// computed from the observed Fisher information. A parameter
+	// that was not estimated, or has no standard error, has a
+	// standard error of NaN.
+	StdErrs []float64
+
+	// N is the total weight of the sample.
+	N float64
+
+	// LogLikelihood is the log-likelihood of the sample under
+	// Dist.
+	LogLikelihood float64
+
+	// AIC and BIC are the Akaike and Bayesian information
+	// criteria of the fit. These are only meaningful when
+	// comparing fits to the same sample; lower is better.
+	AIC, BIC float64
+}
+
+// newFitResult returns a FitResult for a distribution with k
+// estimated parameters.
+func newFitResult(dist DistCommon, params, stdErrs []float64, k int, n, ll float64) *FitResult {
+	return &FitResult{
+		Dist:          dist,
+		Params:        params,
+		StdErrs:       stdErrs,
+		N:             n,
+		LogLikelihood: ll,
+		AIC:           2*float64(k) - 2*ll,
+		BIC:           float64(k)*math.Log(n) - 2*ll,
+	}
+}
+
+// weightedSum returns the sum of f(x) over s, weighted by s.Weights.
+func (s Sample) weightedSum(f func(x float64) float64) float64 {
+	sum := 0.0
+	for i, x := range s.Xs {
+		if s.Weights == nil {
+			sum += f(x)
+		} else if w := s.Weights[i]; w != 0 {
+			sum += w * f(x)
+		}
+	}
+	return sum
+}
+
+// normalLogLikelihood returns the log-likelihood of s under d.
+func normalLogLikelihood(s Sample, d NormalDist) float64 {
+	n := s.Weight()
+	ss := s.weightedSum(func(x float64) float64 {
+		return (x - d.Mu) * (x - d.Mu)
+	})
+	return -n/2*math.Log(2*math.Pi*d.Sigma*d.Sigma) - ss/(2*d.Sigma*d.Sigma)
+}
+
+// FitNormal returns the maximum likelihood estimate of the normal
+// distribution of s. The parameters are Mu and Sigma. If s is
+// weighted, the weights are treated as frequency weights.
+//
+// The maximum likelihood estimate of Sigma is the population standard
+// deviation of s, which is biased. See FitNormalMoments for an
+// unbiased estimate of the variance.
+//
+// This can fail with ErrSampleSize if s is empty or ErrZeroVariance if
+// all values of s are equal.
+func FitNormal(s Sample) (*FitResult, error) {
+	n := s.Weight()
+	if n == 0 {
+		return nil, ErrSampleSize
+	}
+	mu := s.Mean()
+	sigma := math.Sqrt(s.weightedSum(func(x float64) float64 {
+		return (x - mu) * (x - mu)
+	}) / n)
+	if sigma == 0 {
+		return nil, ErrZeroVariance
+	}
+	return fitNormal(s, NormalDist{mu, sigma}), nil
+}
+
+// FitNormalMoments returns the method of moments estimate of the
+// normal distribution of s, using the unbiased estimate of the
+// variance. The parameters are Mu and Sigma. If s is weighted, the
+// weights are treated as frequency weights.
+//
+// This can fail with ErrSampleSize if the total weight of s is less
+// than 2 or ErrZeroVariance if all values of s are equal.
+func FitNormalMoments(s Sample) (*FitResult, error) {
+	if s.Weight() < 2 {
+		return nil, ErrSampleSize
+	}
+	sigma := s.StdDev()
+	if sigma == 0 {
+		return nil, ErrZeroVariance
+	}
+	return fitNormal(s, NormalDist{s.Mean(), sigma}), nil
+}
+
+func fitNormal(s Sample, d NormalDist) *FitResult {
+	n := s.Weight()
+	// The observed information at the MLE is diag(n/σ², 2n/σ²).
+	// We use the same expression for other estimates.
+	se := []float64{d.Sigma / math.Sqrt(n), d.Sigma / math.Sqrt(2*n)}
+	return newFitResult(d, []float64{d.Mu, d.Sigma}, se, 2, n, normalLogLikelihood(s, d))
+}
+
+// binomialLogLikelihood returns the log-likelihood of s under d. It
+// returns ErrSampleSupport if any value of s is not an integer in [0,
+// d.N].
+func binomialLogLikelihood(s Sample, d BinomialDist) (float64, error) {
+	var err error
+	ll := s.weightedSum(func(x float64) float64 {
+		if x != math.Floor(x) || x < 0 || x > float64(d.N) {
+			err = ErrSampleSupport
+			return 0
+		}
+		k := int(x)
+		ll := mathx.Lchoose(d.N, k)
+		if k > 0 {
+			ll += x * math.Log(d.P)
+		}
+		if k < d.N {
+			ll += float64(d.N-k) * math.Log(1-d.P)
+		}
+		return ll
+	})
+	return ll, err
+}
+
+// FitBinomial returns the maximum likelihood estimate of the binomial
+// distribution of s with a known number of trials n. The only
+// parameter is P. If s is weighted, the weights are treated as
+// frequency weights.
+//
+// Note that the method of moments estimate of P for a known n is the
+// same as the maximum likelihood estimate.
+//
+// This can fail with ErrSampleSize if s is empty or ErrSampleSupport
+// if any value of s is not an integer in [0, n]. It panics if n is not
+// positive.
+func FitBinomial(s Sample, n int) (*FitResult, error) {
+	if n <= 0 {
+		panic("FitBinomial requires n > 0")
+	}
+	w := s.Weight()
+	if w == 0 {
+		return nil, ErrSampleSize
+	}
+	d := BinomialDist{N: n, P: s.Mean() / float64(n)}
+	ll, err := binomialLogLikelihood(s, d)
+	if err != nil {
+		return nil, err
+	}
+	se := math.Sqrt(d.P * (1 - d.P) / (w * float64(n)))
+	return newFitResult(d, []float64{d.P}, []float64{se}, 1, w, ll), nil
+}
+
+// FitBinomialMoments returns the method of moments estimate of the
+// binomial distribution of s when the number of trials is unknown. The
+// parameters are N and P. Since N must be an integer, it is rounded to
+// the nearest integer that is at least the largest value in s, and P
+// is then chosen to match the mean. If s is weighted, the weights are
+// treated as frequency weights.
+//
+// The standard error of N is NaN. The standard error of P is computed
+// as if N were known.
+//
+// This can fail with ErrSampleSize if the total weight of s is less
+// than 2, ErrSampleSupport if any value of s is not a non-negative
+// integer, or ErrNoMoments if the sample variance is not less than the
+// sample mean (which a binomial distribution cannot produce).
+func FitBinomialMoments(s Sample) (*FitResult, error) {
+	w := s.Weight()
+	if w < 2 {
+		return nil, ErrSampleSize
+	}
+	mean, variance := s.Mean(), s.Variance()
+	if !(variance < mean) {
+		return nil, ErrNoMoments
+	}
+	_, max := s.Bounds()
+	n := math.Max(max, math.Round(mean*mean/(mean-variance)))
+	d := BinomialDist{N: int(n), P: mean / n}
+	ll, err := binomialLogLikelihood(s, d)
+	if err != nil {
+		return nil, err
+	}
+	se := math.Sqrt(d.P * (1 - d.P) / (w * n))
+	return newFitResult(d, []float64{n, d.P}, []float64{nan, se}, 2, w, ll), nil
+}
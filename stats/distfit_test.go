@@ -0,0 +1,150 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package stats
+
+import (
+	"math"
+	"testing"
+)
+
+// numericSE returns the standard error of parameter i of ll at params
+// computed from a finite-difference estimate of the observed
+// information, ignoring the other parameters.
+func numericSE(ll func(params []float64) float64, params []float64, i int) float64 {
+	h := 1e-4 * math.Max(1, math.Abs(params[i]))
+	at := func(d float64) float64 {
+		p := append([]float64(nil), params...)
+		p[i] += d
+		return ll(p)
+	}
+	d2 := (at(h) - 2*at(0) + at(-h)) / (h * h)
+	return 1 / math.Sqrt(-d2)
+}
+
+func checkFit(t *testing.T, name string, got *FitResult, params, stdErrs []float64, ll float64) {
+	t.Helper()
+	for i := range params {
+		if !aeq(got.Params[i], params[i]) {
+			t.Errorf("%s: param %d = %v, want %v", name, i, got.Params[i], params[i])
+		}
+		if !(math.IsNaN(got.StdErrs[i]) && math.IsNaN(stdErrs[i])) && !aeqTol(got.StdErrs[i], stdErrs[i], 1e-6) {
+			t.Errorf("%s: stderr %d = %v, want %v", name, i, got.StdErrs[i], stdErrs[i])
+		}
+	}
+	if !aeq(got.LogLikelihood, ll) {
+		t.Errorf("%s: log-likelihood = %v, want %v", name, got.LogLikelihood, ll)
+	}
+}
+
+func TestFitNormal(t *testing.T) {
+	s := Sample{Xs: []float64{1, 2, 3, 4, 5}}
+	fit, err := FitNormal(s)
+	if err != nil {
+		t.Fatal(err)
+	}
+	checkFit(t, "FitNormal", fit, []float64{3, math.Sqrt2}, []float64{math.Sqrt(2.0 / 5), math.Sqrt(2.0 / 10)}, -8.827560617423227)
+	if !aeq(fit.AIC, 21.655121234846455) || !aeq(fit.BIC, 20.873997059714654) {
+		t.Errorf("want AIC 21.655, BIC 20.874, got %v, %v", fit.AIC, fit.BIC)
+	}
+	if d := fit.Dist.(NormalDist); d.Mu != 3 || !aeq(d.Sigma, math.Sqrt2) {
+		t.Errorf("Dist = %v", d)
+	}
+
+	// Standard errors agree with the numerical observed
+	// information.
+	ll := func(p []float64) float64 { return normalLogLikelihood(s, NormalDist{p[0], p[1]}) }
+	for i := range fit.Params {
+		if want := numericSE(ll, fit.Params, i); !aeqTol(fit.StdErrs[i], want, 1e-5) {
+			t.Errorf("stderr %d = %v, numerically %v", i, fit.StdErrs[i], want)
+		}
+	}
+
+	// Weights are frequency weights.
+	ws := Sample{Xs: []float64{1, 2, 3}, Weights: []float64{2, 0, 1}}
+	rep := Sample{Xs: []float64{1, 1, 3}}
+	fw, _ := FitNormal(ws)
+	fr, _ := FitNormal(rep)
+	checkFit(t, "weighted FitNormal", fw, fr.Params, fr.StdErrs, fr.LogLikelihood)
+
+	if _, err := FitNormal(Sample{}); err != ErrSampleSize {
+		t.Errorf("want ErrSampleSize, got %v", err)
+	}
+	if _, err := FitNormal(Sample{Xs: []float64{2, 2}}); err != ErrZeroVariance {
+		t.Errorf("want ErrZeroVariance, got %v", err)
+	}
+}
+
+func TestFitNormalMoments(t *testing.T) {
+	s := Sample{Xs: []float64{1, 2, 3, 4, 5}}
+	fit, err := FitNormalMoments(s)
+	if err != nil {
+		t.Fatal(err)
+	}
+	sigma := math.Sqrt(2.5)
+	checkFit(t, "FitNormalMoments", fit, []float64{3, sigma}, []float64{sigma / math.Sqrt(5), sigma / math.Sqrt(10)}, normalLogLikelihood(s, NormalDist{3, sigma}))
+	if _, err := FitNormalMoments(Sample{Xs: []float64{1}}); err != ErrSampleSize {
+		t.Errorf("want ErrSampleSize, got %v", err)
+	}
+}
+
+func TestFitBinomial(t *testing.T) {
+	s := Sample{Xs: []float64{0, 1, 1, 2, 3, 2}}
+	fit, err := FitBinomial(s, 4)
+	if err != nil {
+		t.Fatal(err)
+	}
+	checkFit(t, "FitBinomial", fit, []float64{0.375}, []float64{0.09882117688026186}, -8.135115693975788)
+	if d := fit.Dist.(BinomialDist); d.N != 4 || d.P != 0.375 {
+		t.Errorf("Dist = %v", d)
+	}
+	ll := func(p []float64) float64 {
+		ll, _ := binomialLogLikelihood(s, BinomialDist{N: 4, P: p[0]})
+		return ll
+	}
+	if want := numericSE(ll, fit.Params, 0); !aeqTol(fit.StdErrs[0], want, 1e-5) {
+		t.Errorf("stderr = %v, numerically %v", fit.StdErrs[0], want)
+	}
+
+	// Likelihood agrees with the PMF.
+	want := 0.0
+	for _, x := range s.Xs {
+		want += math.Log(fit.Dist.(BinomialDist).PMF(x))
+	}
+	if !aeq(fit.LogLikelihood, want) {
+		t.Errorf("log-likelihood = %v, want %v", fit.LogLikelihood, want)
+	}
+
+	if _, err := FitBinomial(Sample{Xs: []float64{1, 5}}, 4); err != ErrSampleSupport {
+		t.Errorf("want ErrSampleSupport, got %v", err)
+	}
+	if _, err := FitBinomial(Sample{Xs: []float64{1, 1.5}}, 4); err != ErrSampleSupport {
+		t.Errorf("want ErrSampleSupport, got %v", err)
+	}
+
+	func() {
+		defer func() {
+			if recover() == nil {
+				t.Error("FitBinomial with n = 0 did not panic")
+			}
+		}()
+		FitBinomial(Sample{Xs: []float64{0, 0}}, 0)
+	}()
+}
+
+func TestFitBinomialMoments(t *testing.T) {
+	s := Sample{Xs: []float64{3, 4, 5, 4, 6, 5, 4, 3, 5, 4}}
+	fit, err := FitBinomialMoments(s)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// N = μ²/(μ-σ²) ≈ 5.44 rounds to 5, but the sample contains 6.
+	p := 4.3 / 6
+	ll, _ := binomialLogLikelihood(s, BinomialDist{N: 6, P: p})
+	checkFit(t, "FitBinomialMoments", fit, []float64{6, p}, []float64{nan, math.Sqrt(p * (1 - p) / 60)}, ll)
+
+	if _, err := FitBinomialMoments(Sample{Xs: []float64{0, 5, 10}}); err != ErrNoMoments {
+		t.Errorf("want ErrNoMoments, got %v", err)
+	}
+}
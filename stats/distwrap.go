@@ -0,0 +1,252 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package stats
+
+import (
+	"math"
+	"math/rand"
+)
+
+// TruncatedDist is a continuous distribution restricted to the
+// interval [Lo, Hi]. Its density is the density of the base
+// distribution within [Lo, Hi], renormalized to integrate to 1. Use
+// Truncate to construct a TruncatedDist.
+type TruncatedDist struct {
+	Base   Dist
+	Lo, Hi float64
+
+	// cdfLo is Base.CDF(Lo) and mass is the probability of [Lo,
+	// Hi] under Base.
+	cdfLo, mass float64
+	inv         func(float64) float64
+}
+
+// Truncate returns dist truncated to the interval [lo, hi]. Either
+// bound may be infinite. Truncate panics if lo >= hi or if dist has
+// no probability mass in [lo, hi].
+func Truncate(dist Dist, lo, hi float64) TruncatedDist {
+	if !(lo < hi) {
+		panic("Truncate requires lo < hi")
+	}
+	cdfLo := dist.CDF(lo)
+	mass := dist.CDF(hi) - cdfLo
+	if !(mass > 0) {
+		panic("Truncate interval has zero probability")
+	}
+	return TruncatedDist{Base: dist, Lo: lo, Hi: hi, cdfLo: cdfLo, mass: mass, inv: InvCDF(dist)}
+}
+
+func (d TruncatedDist) PDF(x float64) float64 {
+	if x < d.Lo || x > d.Hi {
+		return 0
+	}
+	return d.Base.PDF(x) / d.mass
+}
+
+func (d TruncatedDist) CDF(x float64) float64 {
+	if x < d.Lo {
+		return 0
+	} else if x >= d.Hi {
+		return 1
+	}
+	return math.Max(0, math.Min(1, (d.Base.CDF(x)-d.cdfLo)/d.mass))
+}
+
+func (d TruncatedDist) InvCDF(y float64) float64 {
+	if y < 0 || y > 1 {
+		return nan
+	} else if y == 0 && !math.IsInf(d.Lo, 0) {
+		return d.Lo
+	} else if y == 1 && !math.IsInf(d.Hi, 0) {
+		return d.Hi
+	}
+	x := d.inv(d.cdfLo + y*d.mass)
+	return math.Max(d.Lo, math.Min(d.Hi, x))
+}
+
+// Bounds returns the bounds of the base distribution restricted to
+// [Lo, Hi]. A finite Lo or Hi is an exact bound.
+func (d TruncatedDist) Bounds() (float64, float64) {
+	bl, bh := d.Base.Bounds()
+	l, h := math.Max(d.Lo, bl), math.Min(d.Hi, bh)
+	if l < h {
+		return l, h
+	}
+	// [Lo, Hi] is in a tail of the base distribution. Derive
+	// the bounds from the truncated distribution itself.
+	l, h = d.Lo, d.Hi
+	if math.IsInf(l, 0) {
+		l = d.InvCDF(0.0013)
+	}
+	if math.IsInf(h, 0) {
+		h = d.InvCDF(0.9987)
+	}
+	return l, h
+}
+
+func (d TruncatedDist) Rand(r *rand.Rand) float64 {
+	var u float64
+	if r == nil {
+		u = rand.Float64()
+	} else {
+		u = r.Float64()
+	}
+	return d.InvCDF(u)
+}
+
+// LocScaleDist is the distribution of Mu + Sigma*X, where X is
+// distributed according to Base. Use LocScale to construct a
+// LocScaleDist.
+type LocScaleDist struct {
+	Base      Dist
+	Mu, Sigma float64
+}
+
+// LocScale returns the distribution of μ + σX, where X is distributed
+// according to dist. σ must be positive.
+func LocScale(dist Dist, μ, σ float64) LocScaleDist {
+	if !(σ > 0) {
+		panic("LocScale requires σ > 0")
+	}
+	return LocScaleDist{dist, μ, σ}
+}
+
+func (d LocScaleDist) PDF(x float64) float64 {
+	return d.Base.PDF((x-d.Mu)/d.Sigma) / d.Sigma
+}
+
+func (d LocScaleDist) CDF(x float64) float64 {
+	return d.Base.CDF((x - d.Mu) / d.Sigma)
+}
+
+func (d LocScaleDist) InvCDF(y float64) float64 {
+	return d.Mu + d.Sigma*InvCDF(d.Base)(y)
+}
+
+func (d LocScaleDist) Bounds() (float64, float64) {
+	l, h := d.Base.Bounds()
+	return d.Mu + d.Sigma*l, d.Mu + d.Sigma*h
+}
+
+func (d LocScaleDist) Rand(r *rand.Rand) float64 {
+	return d.Mu + d.Sigma*Rand(d.Base)(r)
+}
+
+// Mean returns the mean of d. This requires that Base implement
+// Mean() float64; otherwise it returns NaN.
+func (d LocScaleDist) Mean() float64 {
+	if b, ok := d.Base.(interface{ Mean() float64 }); ok {
+		return d.Mu + d.Sigma*b.Mean()
+	}
+	return nan
+}
+
+// Variance returns the variance of d. This requires that Base
+// implement Variance() float64; otherwise it returns NaN.
+func (d LocScaleDist) Variance() float64 {
+	if b, ok := d.Base.(interface{ Variance() float64 }); ok {
+		return d.Sigma * d.Sigma * b.Variance()
+	}
+	return nan
+}
+
+// TransformedDist is the distribution of F(X), where X is distributed
+// according to Base and F is strictly monotonic. Use Transform to
+// construct a TransformedDist.
+type TransformedDist struct {
+	Base Dist
+
+	// F is the transformation, Finv is its inverse, and Fprime
+	// is its derivative.
+	F, Finv, Fprime func(float64) float64
+
+	// decreasing indicates that F is decreasing.
+	decreasing bool
+
+	// lo and hi are the image of the support of Base under F.
+	// The support of d is contained in [lo, hi].
+	lo, hi float64
+}
+
+// Transform returns the distribution of f(X), where X is distributed
+// according to dist. f must be strictly monotonic (either increasing
+// or decreasing) and differentiable, finv must be its inverse, and
+// fprime must be its derivative. finv need only be defined on the
+// range of f.
+//
+// For example, the log-normal distribution is
+//
+//	Transform(NormalDist{μ, σ}, math.Exp, math.Log, math.Exp)
+func Transform(dist Dist, f, finv, fprime func(float64) float64) TransformedDist {
+	d := TransformedDist{Base: dist, F: f, Finv: finv, Fprime: fprime}
+	l, h := dist.Bounds()
+	d.decreasing = f(h) < f(l)
+	// Map the support of dist through f. If a bound of dist is not
+	// exact, the support extends to ±Inf in that direction. f may
+	// not be defined there (e.g., math.Sqrt at -Inf), in which case
+	// leave that side of the support unbounded.
+	if dist.CDF(l) != 0 {
+		l = math.Inf(-1)
+	}
+	if dist.CDF(h) != 1 {
+		h = math.Inf(1)
+	}
+	d.lo, d.hi = f(l), f(h)
+	if d.decreasing {
+		d.lo, d.hi = d.hi, d.lo
+	}
+	if math.IsNaN(d.lo) {
+		d.lo = math.Inf(-1)
+	}
+	if math.IsNaN(d.hi) {
+		d.hi = math.Inf(1)
+	}
+	return d
+}
+
+func (d TransformedDist) PDF(y float64) float64 {
+	if !(d.lo < y && y < d.hi) {
+		return 0
+	}
+	x := d.Finv(y)
+	return d.Base.PDF(x) / math.Abs(d.Fprime(x))
+}
+
+func (d TransformedDist) CDF(y float64) float64 {
+	if y <= d.lo {
+		return 0
+	} else if y >= d.hi {
+		return 1
+	}
+	p := d.Base.CDF(d.Finv(y))
+	if d.decreasing {
+		return 1 - p
+	}
+	return p
+}
+
+func (d TransformedDist) InvCDF(y float64) float64 {
+	if y < 0 || y > 1 {
+		return nan
+	}
+	if d.decreasing {
+		y = 1 - y
+	}
+	return d.F(InvCDF(d.Base)(y))
+}
+
+// Bounds returns the image of the bounds of Base under F.
+func (d TransformedDist) Bounds() (float64, float64) {
+	l, h := d.Base.Bounds()
+	l, h = d.F(l), d.F(h)
+	if d.decreasing {
+		l, h = h, l
+	}
+	return l, h
+}
+
+func (d TransformedDist) Rand(r *rand.Rand) float64 {
+	return d.F(Rand(d.Base)(r))
+}
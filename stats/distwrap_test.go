@@ -0,0 +1,219 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package stats
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+)
+
+func TestTruncate(t *testing.T) {
+	d := Truncate(StdNormal, -1, 2)
+	z := StdNormal.CDF(2) - StdNormal.CDF(-1)
+	for _, x := range []float64{-1.5, -1, 0, 1, 2, 2.5} {
+		wantPDF, wantCDF := 0.0, 0.0
+		if x >= -1 && x <= 2 {
+			wantPDF = StdNormal.PDF(x) / z
+			wantCDF = (StdNormal.CDF(x) - StdNormal.CDF(-1)) / z
+		} else if x > 2 {
+			wantCDF = 1
+		}
+		if got := d.PDF(x); !aeq(got, wantPDF) {
+			t.Errorf("PDF(%v) = %v, want %v", x, got, wantPDF)
+		}
+		if got := d.CDF(x); !aeq(got, wantCDF) {
+			t.Errorf("CDF(%v) = %v, want %v", x, got, wantCDF)
+		}
+	}
+	for _, x := range []float64{-0.9, 0, 1.5} {
+		if got := d.InvCDF(d.CDF(x)); !aeq(got, x) {
+			t.Errorf("InvCDF(CDF(%v)) = %v", x, got)
+		}
+	}
+	if l, h := d.Bounds(); l != -1 || h != 2 {
+		t.Errorf("want bounds [-1, 2], got [%v, %v]", l, h)
+	}
+	testInvCDF(t, d, true)
+
+	r := rand.New(rand.NewSource(1))
+	for i := 0; i < 1000; i++ {
+		if x := d.Rand(r); x < -1 || x > 2 {
+			t.Fatalf("Rand() = %v, want in [-1, 2]", x)
+		}
+	}
+}
+
+func TestTruncateTail(t *testing.T) {
+	// Truncate to a region entirely outside the normal
+	// distribution's bounds.
+	d := Truncate(StdNormal, 4, inf)
+	l, h := d.Bounds()
+	if l != 4 || !(h > 4) || math.IsInf(h, 0) {
+		t.Errorf("want bounds [4, finite], got [%v, %v]", l, h)
+	}
+	if got := d.InvCDF(0); got != 4 {
+		t.Errorf("InvCDF(0) = %v, want 4", got)
+	}
+	if got := d.InvCDF(1); !math.IsInf(got, 1) {
+		t.Errorf("InvCDF(1) = %v, want +Inf", got)
+	}
+	// The mean of a normal truncated below at a is φ(a)/(1-Φ(a)).
+	want := StdNormal.PDF(4) / (1 - StdNormal.CDF(4))
+	r := rand.New(rand.NewSource(1))
+	var xs []float64
+	for i := 0; i < 10000; i++ {
+		xs = append(xs, d.Rand(r))
+	}
+	if got := Mean(xs); !aeqTol(got, want, 0.01) {
+		t.Errorf("mean of random values = %v, want ~%v", got, want)
+	}
+}
+
+func TestLocScale(t *testing.T) {
+	d := LocScale(StdNormal, 3, 2)
+	want := NormalDist{3, 2}
+	for _, x := range []float64{-1, 0, 3, 4.5, 10} {
+		if got := d.PDF(x); !aeq(got, want.PDF(x)) {
+			t.Errorf("PDF(%v) = %v, want %v", x, got, want.PDF(x))
+		}
+		if got := d.CDF(x); !aeq(got, want.CDF(x)) {
+			t.Errorf("CDF(%v) = %v, want %v", x, got, want.CDF(x))
+		}
+	}
+	for _, y := range []float64{0.01, 0.5, 0.9} {
+		if got := d.InvCDF(y); !aeq(got, want.InvCDF(y)) {
+			t.Errorf("InvCDF(%v) = %v, want %v", y, got, want.InvCDF(y))
+		}
+	}
+	if l, h := d.Bounds(); l != -3 || h != 9 {
+		t.Errorf("want bounds [-3, 9], got [%v, %v]", l, h)
+	}
+	if d.Mean() != 3 || d.Variance() != 4 {
+		t.Errorf("want mean 3, variance 4, got %v, %v", d.Mean(), d.Variance())
+	}
+	testInvCDF(t, d, false)
+
+	// Works with bases that lack InvCDF and moments.
+	b := LocScale(bareDist{TDist{5}}, 1, 2)
+	if got := b.CDF(1); !aeq(got, 0.5) {
+		t.Errorf("CDF(1) = %v, want 0.5", got)
+	}
+	if got := b.InvCDF(0.5); !aeqTol(got, 1, 1e-6) {
+		t.Errorf("InvCDF(0.5) = %v, want 1", got)
+	}
+	if !math.IsNaN(b.Mean()) || !math.IsNaN(b.Variance()) {
+		t.Errorf("want mean NaN, variance NaN, got %v, %v", b.Mean(), b.Variance())
+	}
+}
+
+// bareDist hides all methods of a Dist other than those in the Dist
+// interface.
+type bareDist struct {
+	Dist
+}
+
+func TestTransform(t *testing.T) {
+	// Log-normal distribution.
+	d := Transform(NormalDist{0, 1}, math.Exp, math.Log, math.Exp)
+	for _, y := range []float64{-1, 0, 0.5, 1, 3} {
+		wantPDF, wantCDF := 0.0, 0.0
+		if y > 0 {
+			wantPDF = math.Exp(-math.Log(y)*math.Log(y)/2) / (y * math.Sqrt(2*math.Pi))
+			wantCDF = StdNormal.CDF(math.Log(y))
+		}
+		if got := d.PDF(y); !aeq(got, wantPDF) {
+			t.Errorf("PDF(%v) = %v, want %v", y, got, wantPDF)
+		}
+		if got := d.CDF(y); !aeq(got, wantCDF) {
+			t.Errorf("CDF(%v) = %v, want %v", y, got, wantCDF)
+		}
+	}
+	if got := d.InvCDF(0.5); !aeq(got, 1) {
+		t.Errorf("InvCDF(0.5) = %v, want 1", got)
+	}
+	if l, h := d.Bounds(); !aeq(l, math.Exp(-3)) || !aeq(h, math.Exp(3)) {
+		t.Errorf("want bounds [e⁻³, e³], got [%v, %v]", l, h)
+	}
+	r := rand.New(rand.NewSource(1))
+	for i := 0; i < 1000; i++ {
+		if y := d.Rand(r); !(y > 0) {
+			t.Fatalf("Rand() = %v, want > 0", y)
+		}
+	}
+
+	// A decreasing transformation.
+	neg := func(x float64) float64 { return -x }
+	one := func(float64) float64 { return -1 }
+	n := Transform(NormalDist{1, 1}, neg, neg, one)
+	want := NormalDist{-1, 1}
+	for _, y := range []float64{-3, -1, 0, 1} {
+		if got := n.PDF(y); !aeq(got, want.PDF(y)) {
+			t.Errorf("PDF(%v) = %v, want %v", y, got, want.PDF(y))
+		}
+		if got := n.CDF(y); !aeq(got, want.CDF(y)) {
+			t.Errorf("CDF(%v) = %v, want %v", y, got, want.CDF(y))
+		}
+	}
+	if got := n.InvCDF(0.2); !aeq(got, want.InvCDF(0.2)) {
+		t.Errorf("InvCDF(0.2) = %v, want %v", got, want.InvCDF(0.2))
+	}
+	if l, h := n.Bounds(); l != -4 || h != 2 {
+		t.Errorf("want bounds [-4, 2], got [%v, %v]", l, h)
+	}
+
+	// Transformations of a base with finite support, which are not
+	// defined over the whole real line.
+	sq := func(x float64) float64 { return x * x }
+	for _, test := range []struct {
+		name     string
+		d        TransformedDist
+		ys       []float64
+		pdf, cdf func(float64) float64
+		lo, hi   float64
+	}{
+		{
+			// √U, where U is uniform on [0, 1].
+			"sqrt", Transform(BetaDist{1, 1}, math.Sqrt, sq, func(x float64) float64 { return 0.5 / math.Sqrt(x) }),
+			[]float64{-1, 0.1, 0.5, 0.9, 2},
+			func(y float64) float64 { return 2 * y }, sq,
+			0, 1,
+		},
+		{
+			// log U, where U is uniform on [0, 1].
+			"log", Transform(BetaDist{1, 1}, math.Log, math.Exp, func(x float64) float64 { return 1 / x }),
+			[]float64{-5, -0.5, -0.01, 1},
+			math.Exp, math.Exp,
+			math.Inf(-1), 0,
+		},
+		{
+			// -log X, where X ~ Beta(2, 1), which is
+			// exponential with rate 2.
+			"neglog", Transform(BetaDist{2, 1}, func(x float64) float64 { return -math.Log(x) }, func(y float64) float64 { return math.Exp(-y) }, func(x float64) float64 { return -1 / x }),
+			[]float64{-1, 0.1, 1, 3},
+			func(y float64) float64 { return 2 * math.Exp(-2*y) },
+			func(y float64) float64 { return 1 - math.Exp(-2*y) },
+			0, math.Inf(1),
+		},
+	} {
+		for _, y := range test.ys {
+			wantPDF, wantCDF := 0.0, 0.0
+			if y >= test.hi {
+				wantCDF = 1
+			} else if y > test.lo {
+				wantPDF, wantCDF = test.pdf(y), test.cdf(y)
+			}
+			if got := test.d.PDF(y); !aeq(got, wantPDF) {
+				t.Errorf("%s: PDF(%v) = %v, want %v", test.name, y, got, wantPDF)
+			}
+			if got := test.d.CDF(y); !aeq(got, wantCDF) {
+				t.Errorf("%s: CDF(%v) = %v, want %v", test.name, y, got, wantCDF)
+			}
+		}
+		if test.d.lo != test.lo || test.d.hi != test.hi {
+			t.Errorf("%s: support [%v, %v], want [%v, %v]", test.name, test.d.lo, test.d.hi, test.lo, test.hi)
+		}
+	}
+}
@@ -0,0 +1,326 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package stats
+
+import (
+	"math"
+	"sort"
+)
+
+// This file implements information-theoretic quantities and distances
+// between distributions. Each accepts any combination of Dist and
+// DiscreteDist values. Where no closed form is known, they are
+// computed by numerical integration (or summation) over the region
+// where the distributions have non-negligible probability, starting
+// from their Bounds.
+//
+// All logarithms are natural logarithms, so entropies and divergences
+// are in nats.
+
+// divergenceTail is the probability in each infinite tail of a
+// distribution that may be ignored when integrating.
+const divergenceTail = 1e-12
+
+// divergenceQuantiles are quantiles of each continuous distribution
+// used as breakpoints for numerical integration. These ensure that
+// the integrator samples every region of significant probability.
+var divergenceQuantiles = []float64{0.001, 0.01, 0.05, 0.1, 0.2, 0.3, 0.4, 0.5, 0.6, 0.7, 0.8, 0.9, 0.95, 0.99, 0.999}
+
+// Entropy returns the entropy of dist. For a continuous distribution,
+// this is the differential entropy -∫ f(x) log f(x) dx. For a
+// discrete distribution, this is the Shannon entropy -∑ p(x) log p(x).
+//
+// Entropy uses a closed form for NormalDist.
+func Entropy(dist DistCommon) float64 {
+	switch d := dist.(type) {
+	case NormalDist:
+		return 0.5 * math.Log(2*math.Pi*math.E*d.Sigma*d.Sigma)
+	case DiscreteDist:
+		return sumPoints(func(x float64) float64 {
+			return -xlogy(d.PMF(x), d.PMF(x))
+		}, d)
+	case Dist:
+		return integrateDists(func(x float64) float64 {
+			return -xlogy(d.PDF(x), d.PDF(x))
+		}, d)
+	}
+	panic("Entropy requires a Dist or DiscreteDist")
+}
+
+// KLDivergence returns the Kullback-Leibler divergence of q from p,
+// D(p‖q) = ∫ p(x) log(p(x)/q(x)) dx. This is the expected number of
+// extra nats needed to encode samples from p using a code optimized
+// for q. It is not symmetric.
+//
+// If p has mass where q does not (including if one distribution is
+// continuous and the other is discrete), this returns +Inf. Note
+// that numerical underflow of q's density in the tails of p can also
+// produce +Inf.
+//
+// KLDivergence uses a closed form if p and q are both NormalDists.
+func KLDivergence(p, q DistCommon) float64 {
+	if p, ok := p.(NormalDist); ok {
+		if q, ok := q.(NormalDist); ok {
+			d := p.Mu - q.Mu
+			return math.Log(q.Sigma/p.Sigma) + (p.Sigma*p.Sigma+d*d)/(2*q.Sigma*q.Sigma) - 0.5
+		}
+	}
+	fp, fq, discrete, ok := densities(p, q)
+	if !ok {
+		return inf
+	}
+	f := func(x float64) float64 {
+		px := fp(x)
+		if px == 0 {
+			return 0
+		}
+		return px * (math.Log(px) - math.Log(fq(x)))
+	}
+	if discrete {
+		return sumPoints(f, p.(DiscreteDist))
+	}
+	return integrateDists(f, p)
+}
+
+// JSDivergence returns the Jensen-Shannon divergence between p and q.
+// This is the mean of the Kullback-Leibler divergences of p and q from
+// their average m = (p+q)/2. Unlike KLDivergence, it is symmetric and
+// always finite: it ranges from 0 for identical distributions to
+// log 2 for distributions with disjoint support.
+func JSDivergence(p, q DistCommon) float64 {
+	fp, fq, discrete, ok := densities(p, q)
+	if !ok {
+		return math.Ln2
+	}
+	f := func(x float64) float64 {
+		px, qx := fp(x), fq(x)
+		m := (px + qx) / 2
+		return (xlogy(px, px/m) + xlogy(qx, qx/m)) / 2
+	}
+	if discrete {
+		return sumPoints(f, p.(DiscreteDist), q.(DiscreteDist))
+	}
+	return integrateDists(f, p, q)
+}
+
+// HellingerDistance returns the Hellinger distance between p and q,
+//
+//	H(p, q) = √(1 - ∫ √(p(x) q(x)) dx).
+//
+// This ranges from 0 for identical distributions to 1 for
+// distributions with disjoint support.
+//
+// HellingerDistance uses a closed form if p and q are both
+// NormalDists.
+func HellingerDistance(p, q DistCommon) float64 {
+	var bc float64
+	if pn, ok := p.(NormalDist); ok {
+		if qn, ok := q.(NormalDist); ok {
+			v := pn.Sigma*pn.Sigma + qn.Sigma*qn.Sigma
+			d := pn.Mu - qn.Mu
+			bc = math.Sqrt(2*pn.Sigma*qn.Sigma/v) * math.Exp(-d*d/(4*v))
+			return math.Sqrt(math.Max(0, 1-bc))
+		}
+	}
+	fp, fq, discrete, ok := densities(p, q)
+	if !ok {
+		return 1
+	}
+	f := func(x float64) float64 {
+		return math.Sqrt(fp(x) * fq(x))
+	}
+	if discrete {
+		bc = sumPoints(f, p.(DiscreteDist), q.(DiscreteDist))
+	} else {
+		bc = integrateDists(f, p, q)
+	}
+	return math.Sqrt(math.Max(0, 1-bc))
+}
+
+// TotalVariationDistance returns the total variation distance between
+// p and q, which is the largest difference between the probabilities
+// p and q assign to the same event,
+//
+//	δ(p, q) = ½ ∫ |p(x) - q(x)| dx.
+//
+// This ranges from 0 for identical distributions to 1 for
+// distributions with disjoint support.
+func TotalVariationDistance(p, q DistCommon) float64 {
+	fp, fq, discrete, ok := densities(p, q)
+	if !ok {
+		return 1
+	}
+	f := func(x float64) float64 {
+		return math.Abs(fp(x) - fq(x))
+	}
+	if discrete {
+		return math.Min(1, sumPoints(f, p.(DiscreteDist), q.(DiscreteDist))/2)
+	}
+	return math.Min(1, integrateDists(f, p, q)/2)
+}
+
+// Wasserstein1 returns the Wasserstein-1 (or earth mover's) distance
+// between p and q. In one dimension, this is
+//
+//	W₁(p, q) = ∫ |P(x) - Q(x)| dx
+//
+// where P and Q are the CDFs of p and q. Intuitively, this is the
+// minimum "work" required to move the probability mass of p to match
+// q, so, unlike the other distances, it is in the units of the
+// random variable and accounts for how far mass must move. p and q
+// may be any combination of continuous and discrete distributions.
+//
+// Wasserstein1 uses a closed form if p and q are NormalDists with the
+// same standard deviation.
+func Wasserstein1(p, q DistCommon) float64 {
+	if pn, ok := p.(NormalDist); ok {
+		if qn, ok := q.(NormalDist); ok && pn.Sigma == qn.Sigma {
+			return math.Abs(pn.Mu - qn.Mu)
+		}
+	}
+	return integrateDists(func(x float64) float64 {
+		return math.Abs(p.CDF(x) - q.CDF(x))
+	}, p, q)
+}
+
+// densities returns the density functions of p and q. If both are
+// discrete, these are their PMFs restricted to their support points
+// and discrete is true. If both are continuous, these are their PDFs.
+// Otherwise, ok is false, indicating that p and q are mutually
+// singular.
+func densities(p, q DistCommon) (fp, fq func(float64) float64, discrete, ok bool) {
+	pd, pDisc := p.(DiscreteDist)
+	qd, qDisc := q.(DiscreteDist)
+	if pDisc && qDisc {
+		return pointPMF(pd), pointPMF(qd), true, true
+	} else if pDisc || qDisc {
+		return nil, nil, false, false
+	}
+	pc, pCont := p.(Dist)
+	qc, qCont := q.(Dist)
+	if !pCont || !qCont {
+		panic("distributions must be Dist or DiscreteDist")
+	}
+	return pc.PDF, qc.PDF, false, true
+}
+
+// pointPMF returns the PMF of d, except that it is 0 at any x that is
+// not exactly a point of d's support. (DiscreteDist.PMF instead
+// rounds down to the nearest point.)
+func pointPMF(d DiscreteDist) func(float64) float64 {
+	step := d.Step()
+	return func(x float64) float64 {
+		k := x / step
+		if math.Abs(k-math.Round(k)) > 1e-9*math.Max(1, math.Abs(k)) {
+			return 0
+		}
+		return d.PMF(math.Round(k) * step)
+	}
+}
+
+// xlogy returns x*log(y), or 0 if x is 0.
+func xlogy(x, y float64) float64 {
+	if x == 0 {
+		return 0
+	}
+	return x * math.Log(y)
+}
+
+// supportPoints returns the points of the support of each of dists
+// with non-negligible probability, in increasing order.
+func supportPoints(dists ...DiscreteDist) []float64 {
+	var pts []float64
+	for _, d := range dists {
+		lo, hi, _, _ := tailBounds(d, divergenceTail)
+		step := d.Step()
+		k0, k1 := math.Round(lo/step), math.Round(hi/step)
+		for k := k0; k <= k1; k++ {
+			pts = append(pts, k*step)
+		}
+	}
+	return uniqueFloats(pts)
+}
+
+// uniqueFloats sorts xs and removes duplicates.
+func uniqueFloats(xs []float64) []float64 {
+	sort.Float64s(xs)
+	out := xs[:0]
+	for i, x := range xs {
+		if i == 0 || x != out[len(out)-1] {
+			out = append(out, x)
+		}
+	}
+	return out
+}
+
+// sumPoints returns the sum of f over the support points of dists.
+func sumPoints(f func(float64) float64, dists ...DiscreteDist) float64 {
+	sum := 0.0
+	for _, x := range supportPoints(dists...) {
+		sum += f(x)
+	}
+	return sum
+}
+
+// integrateDists returns the integral of f over the region where any
+// of dists has non-negligible probability. f must be 0 outside this
+// region.
+//
+// The integral is split at the quantiles of continuous distributions
+// and at the support points of discrete distributions, so f may have
+// discontinuities at the support points of any discrete
+// distributions in dists.
+func integrateDists(f func(float64) float64, dists ...DistCommon) float64 {
+	var pts []float64
+	for _, d := range dists {
+		if d, ok := d.(DiscreteDist); ok {
+			pts = append(pts, supportPoints(d)...)
+			continue
+		}
+		lo, hi, _, _ := tailBounds(d, divergenceTail)
+		pts = append(pts, lo, hi)
+		inv := InvCDF(d)
+		for _, y := range divergenceQuantiles {
+			pts = append(pts, inv(y))
+		}
+	}
+	pts = uniqueFloats(pts)
+
+	const tol = 1e-10
+	sum := 0.0
+	for i := 1; i < len(pts); i++ {
+		a, b := pts[i-1], pts[i]
+		if math.IsInf(a, 0) || math.IsInf(b, 0) {
+			continue
+		}
+		// Integrate over [a, b), since f may be discontinuous
+		// at b.
+		b = math.Nextafter(b, a)
+		sum += adaptiveSimpson(f, a, b, tol)
+	}
+	return sum
+}
+
+// adaptiveSimpson returns the integral of f over [a, b] using adaptive
+// Simpson's rule with an absolute error tolerance of tol.
+func adaptiveSimpson(f func(float64) float64, a, b, tol float64) float64 {
+	fa, fm, fb := f(a), f((a+b)/2), f(b)
+	whole := (b - a) / 6 * (fa + 4*fm + fb)
+	return adaptiveSimpsonRec(f, a, b, fa, fm, fb, whole, tol, 30)
+}
+
+func adaptiveSimpsonRec(f func(float64) float64, a, b, fa, fm, fb, whole, tol float64, depth int) float64 {
+	m := (a + b) / 2
+	lm, rm := (a+m)/2, (m+b)/2
+	flm, frm := f(lm), f(rm)
+	left := (m - a) / 6 * (fa + 4*flm + fm)
+	right := (b - m) / 6 * (fm + 4*frm + fb)
+	delta := left + right - whole
+	if depth <= 0 || math.Abs(delta) <= 15*tol {
+		return left + right + delta/15
+	}
+	return adaptiveSimpsonRec(f, a, m, fa, flm, fm, left, tol/2, depth-1) +
+		adaptiveSimpsonRec(f, m, b, fm, frm, fb, right, tol/2, depth-1)
+}
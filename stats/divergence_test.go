@@ -0,0 +1,152 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package stats
+
+import (
+	"math"
+	"testing"
+)
+
+// In these tests, LocScale(StdNormal, μ, σ) is a normal distribution
+// that does not use the closed forms for NormalDist, so it checks the
+// numerical integration.
+
+func TestEntropy(t *testing.T) {
+	want := 0.5 * math.Log(2*math.Pi*math.E*4)
+	if got := Entropy(NormalDist{1, 2}); !aeq(got, want) {
+		t.Errorf("Entropy(NormalDist) = %v, want %v", got, want)
+	}
+	if got := Entropy(LocScale(StdNormal, 1, 2)); !aeqTol(got, want, 1e-8) {
+		t.Errorf("Entropy(LocScale) = %v, want %v", got, want)
+	}
+	if got := Entropy(BinomialDist{N: 4, P: 0.5}); !aeq(got, 1.4075317407193153) {
+		t.Errorf("Entropy(BinomialDist) = %v, want 1.4075317407193153", got)
+	}
+}
+
+func TestKLDivergence(t *testing.T) {
+	// log 2 + (1 + 1)/8 - 1/2
+	want := 0.4431471805599453
+	if got := KLDivergence(NormalDist{0, 1}, NormalDist{1, 2}); !aeq(got, want) {
+		t.Errorf("closed form KL = %v, want %v", got, want)
+	}
+	if got := KLDivergence(LocScale(StdNormal, 0, 1), LocScale(StdNormal, 1, 2)); !aeqTol(got, want, 1e-8) {
+		t.Errorf("numerical KL = %v, want %v", got, want)
+	}
+	if got := KLDivergence(NormalDist{0, 1}, NormalDist{0, 1}); got != 0 {
+		t.Errorf("KL of identical distributions = %v, want 0", got)
+	}
+
+	p, q := BinomialDist{N: 1, P: 0.5}, BinomialDist{N: 1, P: 0.9}
+	if got := KLDivergence(p, q); !aeq(got, 0.5108256237659907) {
+		t.Errorf("discrete KL = %v, want 0.5108256237659907", got)
+	}
+	if got := KLDivergence(BinomialDist{N: 2, P: 0.5}, p); !math.IsInf(got, 1) {
+		t.Errorf("KL with unsupported mass = %v, want +Inf", got)
+	}
+	if got := KLDivergence(p, StdNormal); !math.IsInf(got, 1) {
+		t.Errorf("KL of discrete from continuous = %v, want +Inf", got)
+	}
+}
+
+func TestJSDivergence(t *testing.T) {
+	p, q := BinomialDist{N: 1, P: 0.5}, BinomialDist{N: 1, P: 0.9}
+	if got := JSDivergence(p, q); !aeq(got, 0.10174922507919676) {
+		t.Errorf("discrete JS = %v, want 0.10174922507919676", got)
+	}
+	// Points on different lattices are distinct.
+	h := DiscreteMixture{[]float64{1}, []DiscreteDist{halfStep{}}}
+	if got := JSDivergence(h, BinomialDist{N: 0, P: 0.5}); !aeq(got, math.Ln2) {
+		t.Errorf("JS of disjoint distributions = %v, want log 2", got)
+	}
+
+	a, b := LocScale(StdNormal, 0, 1), LocScale(StdNormal, 1, 2)
+	ab, ba := JSDivergence(a, b), JSDivergence(b, a)
+	if !aeqTol(ab, ba, 1e-9) {
+		t.Errorf("JS not symmetric: %v vs %v", ab, ba)
+	}
+	if !(ab > 0 && ab < math.Ln2) {
+		t.Errorf("JS = %v, want in (0, log 2)", ab)
+	}
+	if got := JSDivergence(a, a); !aeqTol(got, 0, 1e-12) {
+		t.Errorf("JS of identical distributions = %v, want 0", got)
+	}
+	if got := JSDivergence(p, a); got != math.Ln2 {
+		t.Errorf("JS of discrete and continuous = %v, want log 2", got)
+	}
+}
+
+// halfStep is a point mass at 0.5 with step 0.5.
+type halfStep struct{}
+
+func (halfStep) PMF(x float64) float64 {
+	if math.Floor(x*2) == 1 {
+		return 1
+	}
+	return 0
+}
+
+func (h halfStep) CDF(x float64) float64 {
+	if x >= 0.5 {
+		return 1
+	}
+	return 0
+}
+
+func (halfStep) Bounds() (float64, float64) { return 0.5, 0.5 }
+
+func (halfStep) Step() float64 { return 0.5 }
+
+func TestHellingerDistance(t *testing.T) {
+	want := 0.38625708776326656
+	if got := HellingerDistance(NormalDist{0, 1}, NormalDist{1, 2}); !aeq(got, want) {
+		t.Errorf("closed form Hellinger = %v, want %v", got, want)
+	}
+	if got := HellingerDistance(LocScale(StdNormal, 0, 1), LocScale(StdNormal, 1, 2)); !aeqTol(got, want, 1e-8) {
+		t.Errorf("numerical Hellinger = %v, want %v", got, want)
+	}
+	p, q := BinomialDist{N: 1, P: 0.5}, BinomialDist{N: 1, P: 0.9}
+	if got := HellingerDistance(p, q); !aeq(got, 0.32491969623290634) {
+		t.Errorf("discrete Hellinger = %v, want 0.32491969623290634", got)
+	}
+	if got := HellingerDistance(p, StdNormal); got != 1 {
+		t.Errorf("Hellinger of discrete and continuous = %v, want 1", got)
+	}
+}
+
+func TestTotalVariationDistance(t *testing.T) {
+	// For normals with equal variance, δ = 2Φ(|Δμ|/2σ) - 1.
+	want := 2*StdNormal.CDF(0.75) - 1
+	if got := TotalVariationDistance(NormalDist{0, 2}, NormalDist{3, 2}); !aeqTol(got, want, 1e-8) {
+		t.Errorf("TV = %v, want %v", got, want)
+	}
+	p, q := BinomialDist{N: 1, P: 0.5}, BinomialDist{N: 1, P: 0.9}
+	if got := TotalVariationDistance(p, q); !aeq(got, 0.4) {
+		t.Errorf("discrete TV = %v, want 0.4", got)
+	}
+	if got := TotalVariationDistance(p, StdNormal); got != 1 {
+		t.Errorf("TV of discrete and continuous = %v, want 1", got)
+	}
+}
+
+func TestWasserstein1(t *testing.T) {
+	if got := Wasserstein1(NormalDist{0, 2}, NormalDist{3, 2}); got != 3 {
+		t.Errorf("closed form W1 = %v, want 3", got)
+	}
+	if got := Wasserstein1(LocScale(StdNormal, 0, 2), LocScale(StdNormal, 3, 2)); !aeqTol(got, 3, 1e-8) {
+		t.Errorf("numerical W1 = %v, want 3", got)
+	}
+	// W1 between N(0, 1) and N(0, 2) is E|Z|·(2-1) = √(2/π).
+	if got := Wasserstein1(NormalDist{0, 1}, NormalDist{0, 2}); !aeqTol(got, math.Sqrt(2/math.Pi), 1e-8) {
+		t.Errorf("W1 = %v, want %v", got, math.Sqrt(2/math.Pi))
+	}
+	p, q := BinomialDist{N: 1, P: 0.5}, BinomialDist{N: 1, P: 0.9}
+	if got := Wasserstein1(p, q); !aeqTol(got, 0.4, 1e-9) {
+		t.Errorf("discrete W1 = %v, want 0.4", got)
+	}
+	if got := Wasserstein1(DeltaDist{0}, p); !aeqTol(got, 0.5, 1e-9) {
+		t.Errorf("mixed W1 = %v, want 0.5", got)
+	}
+}
@@ -0,0 +1,179 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package stats
+
+import (
+	"math"
+	"math/rand"
+	"sort"
+)
+
+// An ECDF is the empirical distribution of a Sample. It places
+// probability mass at each sample value in proportion to its weight,
+// so its CDF is a step function.
+//
+// An ECDF implements DistCommon, so it can be used with InvCDF and
+// Rand. It is not a DiscreteDist because its support is not
+// generally a regular lattice.
+type ECDF struct {
+	// s is the sorted sample.
+	s Sample
+
+	// xs are the distinct sample values with non-zero weight, in
+	// increasing order.
+	xs []float64
+
+	// cum[i] is the total weight of all values <= xs[i].
+	cum []float64
+}
+
+// NewECDF returns the empirical distribution of s. s must have at
+// least one value with non-zero weight.
+//
+// The ECDF does not share any data with s.
+func NewECDF(s Sample) *ECDF {
+	s = *s.Copy().Sort()
+	e := &ECDF{s: s}
+	total := 0.0
+	for i, x := range s.Xs {
+		w := 1.0
+		if s.Weights != nil {
+			w = s.Weights[i]
+		}
+		if w == 0 {
+			continue
+		}
+		total += w
+		if len(e.xs) > 0 && e.xs[len(e.xs)-1] == x {
+			e.cum[len(e.cum)-1] = total
+		} else {
+			e.xs = append(e.xs, x)
+			e.cum = append(e.cum, total)
+		}
+	}
+	if total == 0 {
+		panic("ECDF of empty sample")
+	}
+	return e
+}
+
+// Weight returns the total weight of the underlying sample.
+func (e *ECDF) Weight() float64 {
+	return e.cum[len(e.cum)-1]
+}
+
+// CDF returns the fraction of the sample weight at values <= x.
+func (e *ECDF) CDF(x float64) float64 {
+	i := sort.Search(len(e.xs), func(i int) bool { return e.xs[i] > x })
+	if i == 0 {
+		return 0
+	} else if i == len(e.xs) {
+		return 1
+	}
+	return e.cum[i-1] / e.Weight()
+}
+
+// PMF returns the probability mass of the ECDF at exactly x. This is
+// the size of the jump in the CDF at x. Unlike DiscreteDist.PMF, x is
+// not rounded, so PMF returns 0 for any x that is not a sample value.
+func (e *ECDF) PMF(x float64) float64 {
+	i := sort.SearchFloat64s(e.xs, x)
+	if i == len(e.xs) || e.xs[i] != x {
+		return 0
+	}
+	w := e.cum[i]
+	if i > 0 {
+		w -= e.cum[i-1]
+	}
+	return w / e.Weight()
+}
+
+// Bounds returns the minimum and maximum values of the sample. These
+// are exact bounds: CDF(x) is 0 for all x below the minimum and 1 for
+// all x at or above the maximum.
+func (e *ECDF) Bounds() (float64, float64) {
+	return e.xs[0], e.xs[len(e.xs)-1]
+}
+
+// InvCDF returns the y'th quantile of the sample. This is equivalent
+// to Sample.Quantile, including its interpolation between sample
+// values, so it is a continuous approximation to the inverse of the
+// step function CDF.
+//
+// If y < 0 or y > 1, InvCDF returns NaN.
+func (e *ECDF) InvCDF(y float64) float64 {
+	if y < 0 || y > 1 {
+		return nan
+	}
+	return e.s.Quantile(y)
+}
+
+// Rand returns a random value drawn from the ECDF. Each sample value
+// is returned with probability proportional to its weight, so this
+// is equivalent to drawing from the sample with replacement (as in
+// the bootstrap). If r is nil, it uses the default global source.
+//
+// Note that this differs from the generic sampler that would be
+// derived from InvCDF, which interpolates between sample values.
+func (e *ECDF) Rand(r *rand.Rand) float64 {
+	var u float64
+	if r == nil {
+		u = rand.Float64()
+	} else {
+		u = r.Float64()
+	}
+	u *= e.Weight()
+	i := sort.Search(len(e.cum)-1, func(i int) bool { return e.cum[i] > u })
+	return e.xs[i]
+}
+
+// Mean returns the mean of the ECDF, which is the mean of the sample.
+func (e *ECDF) Mean() float64 {
+	return e.s.Mean()
+}
+
+// Variance returns the variance of the ECDF. Note that this is the
+// population variance of the sample (the sum of squared deviations
+// divided by the total weight), which differs from Sample.Variance.
+func (e *ECDF) Variance() float64 {
+	n := e.Weight()
+	if n <= 1 {
+		return 0
+	}
+	return e.s.Variance() * (n - 1) / n
+}
+
+// DKWEpsilon returns the half-width ε of a confidence band around the
+// ECDF given by the Dvoretzky-Kiefer-Wolfowitz inequality [1,2]. With
+// probability at least confidence, the true CDF F satisfies
+//
+//	|F(x) - e.CDF(x)| <= ε
+//
+// for all x simultaneously. ε is √(ln(2/α)/(2n)), where α = 1 -
+// confidence and n is the total weight of the sample.
+//
+// [1] Dvoretzky, A.; Kiefer, J.; Wolfowitz, J. (1956). "Asymptotic
+// Minimax Character of the Sample Distribution Function and of the
+// Classical Multinomial Estimator". Annals of Mathematical Statistics
+// 27 (3): 642-669.
+//
+// [2] Massart, P. (1990). "The Tight Constant in the
+// Dvoretzky-Kiefer-Wolfowitz Inequality". Annals of Probability 18
+// (3): 1269-1283.
+func (e *ECDF) DKWEpsilon(confidence float64) float64 {
+	if confidence <= 0 {
+		return 0
+	} else if confidence >= 1 {
+		return 1
+	}
+	return math.Sqrt(math.Log(2/(1-confidence)) / (2 * e.Weight()))
+}
+
+// CDFBand returns the lower and upper bounds of the DKW confidence
+// band of the CDF at x. See DKWEpsilon.
+func (e *ECDF) CDFBand(x, confidence float64) (lo, hi float64) {
+	y, eps := e.CDF(x), e.DKWEpsilon(confidence)
+	return math.Max(0, y-eps), math.Min(1, y+eps)
+}
@@ -0,0 +1,118 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package stats
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+)
+
+func TestECDF(t *testing.T) {
+	s := Sample{Xs: []float64{40, 15, 35, 20, 50, 20}}
+	e := NewECDF(s)
+	if s.Xs[0] != 40 {
+		t.Fatalf("NewECDF modified its argument")
+	}
+	testFunc(t, "CDF", e.CDF, map[float64]float64{
+		10: 0,
+		15: 1.0 / 6,
+		19: 1.0 / 6,
+		20: 3.0 / 6,
+		45: 5.0 / 6,
+		50: 1,
+		60: 1,
+	})
+	testFunc(t, "PMF", e.PMF, map[float64]float64{
+		10: 0,
+		15: 1.0 / 6,
+		20: 2.0 / 6,
+		21: 0,
+		50: 1.0 / 6,
+	})
+	if l, h := e.Bounds(); l != 15 || h != 50 {
+		t.Errorf("want bounds [15, 50], got [%v, %v]", l, h)
+	}
+
+	// InvCDF must agree with Sample.Quantile.
+	sorted := *s.Copy().Sort()
+	for _, y := range []float64{0, 0.05, 0.3, 0.5, 0.77, 1} {
+		if got, want := e.InvCDF(y), sorted.Quantile(y); got != want {
+			t.Errorf("InvCDF(%v) = %v, want %v", y, got, want)
+		}
+		if got, want := InvCDF(e)(y), sorted.Quantile(y); got != want {
+			t.Errorf("stats.InvCDF(e)(%v) = %v, want %v", y, got, want)
+		}
+	}
+	if !math.IsNaN(e.InvCDF(-0.1)) || !math.IsNaN(e.InvCDF(1.1)) {
+		t.Errorf("want InvCDF out of [0, 1] to be NaN")
+	}
+
+	if got, want := e.Mean(), s.Mean(); !aeq(got, want) {
+		t.Errorf("Mean() = %v, want %v", got, want)
+	}
+	if got, want := e.Variance(), s.Variance()*5/6; !aeq(got, want) {
+		t.Errorf("Variance() = %v, want %v", got, want)
+	}
+}
+
+func TestECDFWeighted(t *testing.T) {
+	s := Sample{Xs: []float64{3, 1, 2, 5}, Weights: []float64{1, 2, 0, 1}}
+	e := NewECDF(s)
+	testFunc(t, "CDF", e.CDF, map[float64]float64{
+		0: 0,
+		1: 0.5,
+		2: 0.5,
+		3: 0.75,
+		5: 1,
+	})
+	if got := e.PMF(2); got != 0 {
+		t.Errorf("PMF(2) = %v, want 0", got)
+	}
+	if l, h := e.Bounds(); l != 1 || h != 5 {
+		t.Errorf("want bounds [1, 5], got [%v, %v]", l, h)
+	}
+	if got := e.Weight(); got != 4 {
+		t.Errorf("Weight() = %v, want 4", got)
+	}
+}
+
+func TestECDFRand(t *testing.T) {
+	e := NewECDF(Sample{Xs: []float64{1, 2, 3}, Weights: []float64{1, 2, 1}})
+	r := rand.New(rand.NewSource(1))
+	counts := map[float64]int{}
+	const n = 40000
+	for i := 0; i < n; i++ {
+		counts[Rand(e)(r)]++
+	}
+	if len(counts) != 3 {
+		t.Fatalf("want only sample values, got %v", counts)
+	}
+	for x, want := range map[float64]float64{1: 0.25, 2: 0.5, 3: 0.25} {
+		if got := float64(counts[x]) / n; math.Abs(got-want) > 0.01 {
+			t.Errorf("frequency of %v = %v, want %v", x, got, want)
+		}
+	}
+}
+
+func TestECDFBand(t *testing.T) {
+	xs := make([]float64, 100)
+	for i := range xs {
+		xs[i] = float64(i)
+	}
+	e := NewECDF(Sample{Xs: xs})
+	// ε = √(ln(2/0.05)/200)
+	if got, want := e.DKWEpsilon(0.95), 0.13581015157406193; !aeq(got, want) {
+		t.Errorf("DKWEpsilon(0.95) = %v, want %v", got, want)
+	}
+	lo, hi := e.CDFBand(49, 0.95)
+	if !aeq(lo, 0.5-0.13581015157406193) || !aeq(hi, 0.5+0.13581015157406193) {
+		t.Errorf("CDFBand(49, 0.95) = [%v, %v]", lo, hi)
+	}
+	lo, hi = e.CDFBand(-1, 0.95)
+	if lo != 0 || !aeq(hi, 0.13581015157406193) {
+		t.Errorf("CDFBand(-1, 0.95) = [%v, %v], want clamped to 0", lo, hi)
+	}
+}
@@ -0,0 +1,233 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package stats
+
+import (
+	"math"
+	"math/rand"
+	"sort"
+)
+
+// HodgesLehmannResult is the result of HodgesLehmann.
+type HodgesLehmannResult struct {
+	// N1 and N2 are the sizes of the input samples.
+	N1, N2 int
+
+	// Shift is the Hodges-Lehmann estimate of the shift in
+	// location from the second sample to the first. This is the
+	// median of the N1*N2 pairwise differences x1[i] - x2[j].
+	Shift float64
+
+	// Lo and Hi are the bounds of the confidence interval of the
+	// shift. These are pairwise differences, or negative or
+	// positive infinity if the samples are too small for the
+	// requested confidence level.
+	Lo, Hi float64
+
+	// Confidence is the actual confidence level of the interval
+	// [Lo, Hi]. If Exact is true, this is >= the requested
+	// confidence. Otherwise, it is the confidence according to
+	// the normal approximation.
+	Confidence float64
+
+	// Exact indicates that the confidence interval was computed
+	// using the exact distribution of the Mann-Whitney U
+	// statistic. Otherwise, it was computed using a normal
+	// approximation.
+	Exact bool
+}
+
+// HodgesLehmann returns the Hodges-Lehmann estimate of the shift in
+// location between samples x1 and x2, and its confidence interval
+// at the given confidence level (e.g., 0.95).
+//
+// This is the location estimator that corresponds to the
+// Mann-Whitney U-test: the confidence interval is the set of shifts
+// d for which the U-test does not reject the hypothesis that x1 - d
+// and x2 have the same location. Hence, it assumes that the two
+// samples come from continuous distributions that differ only by a
+// shift.
+//
+// This uses the exact distribution of U if both samples are no larger
+// than MannWhitneyExactLimit and a normal approximation with the
+// continuity correction otherwise. It finds order statistics of the
+// pairwise differences without computing all of them, so it takes
+// O((N1+N2) log(N1+N2)) expected time.
+//
+// If confidence <= 0, the interval is [Shift, Shift].
+//
+// This can fail with ErrSampleSize if either sample is empty.
+//
+// Hodges, J. L.; Lehmann, E. L. (1963). "Estimates of location based
+// on rank tests". Annals of Mathematical Statistics 34 (2): 598–611.
+//
+// Bauer, David F. (1972). "Constructing Confidence Sets Using Rank
+// Statistics". Journal of the American Statistical Association 67
+// (339): 687–690.
+func HodgesLehmann(x1, x2 []float64, confidence float64) (*HodgesLehmannResult, error) {
+	n1, n2 := len(x1), len(x2)
+	if n1 == 0 || n2 == 0 {
+		return nil, ErrSampleSize
+	}
+
+	x1 = append([]float64(nil), x1...)
+	x2 = append([]float64(nil), x2...)
+	sort.Float64s(x1)
+	sort.Float64s(x2)
+	diffs := newPairwiseDiffs(x1, x2)
+
+	res := &HodgesLehmannResult{N1: n1, N2: n2}
+	nn := n1 * n2
+	if nn%2 == 1 {
+		res.Shift = diffs.selectK(nn / 2)
+	} else {
+		res.Shift = (diffs.selectK(nn/2-1) + diffs.selectK(nn/2)) / 2
+	}
+	if confidence <= 0 {
+		res.Lo, res.Hi = res.Shift, res.Shift
+		return res, nil
+	}
+
+	// Find the largest k such that Pr[U < k] <= α/2. Then the
+	// confidence interval is bounded by the k'th smallest and
+	// k'th largest differences (1-based), since U is the number
+	// of differences that are > 0.
+	alpha := 1 - confidence
+	var k int
+	if n1 <= MannWhitneyExactLimit && n2 <= MannWhitneyExactLimit {
+		res.Exact = true
+		// We only need the lower half of the U distribution,
+		// since confidence > 0, so α/2 < 0.5.
+		pmf := UDist{N1: n1, N2: n2}.p(nn / 2)
+		cdf := 0.0 // Pr[U < k]
+		for k < len(pmf) && cdf+pmf[k] <= alpha/2 {
+			cdf += pmf[k]
+			k++
+		}
+		res.Confidence = 1 - 2*cdf
+	} else {
+		μ_U := float64(nn) / 2
+		σ_U := math.Sqrt(float64(nn*(n1+n2+1)) / 12)
+		z := -StdNormal.InvCDF(alpha / 2)
+		// With continuity correction, Pr[U < k] ≈
+		// Φ((k - 0.5 - μ_U) / σ_U).
+		kf := math.Max(0, math.Floor(μ_U+0.5-z*σ_U))
+		k = int(kf)
+		res.Confidence = 1 - 2*StdNormal.CDF((kf-0.5-μ_U)/σ_U)
+	}
+	if k == 0 {
+		res.Lo, res.Hi = -inf, inf
+	} else {
+		res.Lo, res.Hi = diffs.selectK(k-1), diffs.selectK(nn-k)
+	}
+	return res, nil
+}
+
+// pairwiseDiffs represents the matrix of pairwise differences
+// x[i] - y[j] of two sorted samples, for selecting order statistics
+// of these differences without computing them all.
+type pairwiseDiffs struct {
+	x, y []float64
+	r    *rand.Rand
+
+	// lo, hi, less, and leq are scratch space for selectK.
+	lo, hi, less, leq []int
+}
+
+func newPairwiseDiffs(x, y []float64) *pairwiseDiffs {
+	n := len(x)
+	return &pairwiseDiffs{
+		x: x, y: y,
+		// selectK's result does not depend on the random
+		// choices, so a fixed seed is fine.
+		r:  rand.New(rand.NewSource(1)),
+		lo: make([]int, n), hi: make([]int, n),
+		less: make([]int, n), leq: make([]int, n),
+	}
+}
+
+// at returns element (i, j) of the matrix. Each row and column of
+// the matrix is sorted in increasing order.
+func (d *pairwiseDiffs) at(i, j int) float64 {
+	return d.x[i] - d.y[len(d.y)-1-j]
+}
+
+// selectK returns the k'th smallest (0-based) pairwise difference.
+func (d *pairwiseDiffs) selectK(k int) float64 {
+	// This is the randomized algorithm of Monahan (1984) applied
+	// to the two-sample case. Row i of the matrix has candidate
+	// columns [lo[i], hi[i]). We repeatedly choose a random
+	// candidate as a pivot and count the elements less than it,
+	// which takes linear time because the matrix is sorted. This
+	// discards a constant fraction of the candidates in
+	// expectation, so it takes O(log(N1*N2)) rounds.
+	//
+	// Monahan, John F. (1984). "Algorithm 616: fast computation
+	// of the Hodges-Lehmann location estimator". ACM Transactions
+	// on Mathematical Software 10 (3): 265–270.
+	n1, n2 := len(d.x), len(d.y)
+	for i := range d.lo {
+		d.lo[i], d.hi[i] = 0, n2
+	}
+	for {
+		total := 0
+		for i := range d.lo {
+			total += d.hi[i] - d.lo[i]
+		}
+		if total <= n1+n2 {
+			break
+		}
+
+		// Pick a random candidate as the pivot.
+		c := d.r.Intn(total)
+		var pivot float64
+		for i := range d.lo {
+			if c < d.hi[i]-d.lo[i] {
+				pivot = d.at(i, d.lo[i]+c)
+				break
+			}
+			c -= d.hi[i] - d.lo[i]
+		}
+
+		// Count the elements in each row that are < pivot
+		// and <= pivot. These counts decrease with i.
+		nLess, nLeq := 0, 0
+		jl, jq := n2, n2
+		for i := 0; i < n1; i++ {
+			for jl > 0 && d.at(i, jl-1) >= pivot {
+				jl--
+			}
+			for jq > 0 && d.at(i, jq-1) > pivot {
+				jq--
+			}
+			d.less[i], d.leq[i] = jl, jq
+			nLess += jl
+			nLeq += jq
+		}
+
+		if k < nLess {
+			for i, l := range d.less {
+				d.hi[i] = minint(d.hi[i], l)
+			}
+		} else if k >= nLeq {
+			for i, l := range d.leq {
+				d.lo[i] = maxint(d.lo[i], l)
+			}
+		} else {
+			return pivot
+		}
+	}
+
+	// Few enough candidates remain to select from them directly.
+	var cands []float64
+	before := 0
+	for i := range d.lo {
+		before += d.lo[i]
+		for j := d.lo[i]; j < d.hi[i]; j++ {
+			cands = append(cands, d.at(i, j))
+		}
+	}
+	return selectK(cands, k-before)
+}
@@ -0,0 +1,129 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package stats
+
+import (
+	"math"
+	"math/rand"
+	"sort"
+	"testing"
+)
+
+func TestPairwiseDiffsSelect(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	for _, n := range [][2]int{{1, 1}, {1, 7}, {5, 3}, {40, 60}, {100, 100}} {
+		x, y := make([]float64, n[0]), make([]float64, n[1])
+		for i := range x {
+			// Include duplicate values.
+			x[i] = math.Floor(r.NormFloat64() * 10)
+		}
+		for i := range y {
+			y[i] = math.Floor(r.NormFloat64() * 10)
+		}
+		sort.Float64s(x)
+		sort.Float64s(y)
+		var all []float64
+		for _, xi := range x {
+			for _, yj := range y {
+				all = append(all, xi-yj)
+			}
+		}
+		sort.Float64s(all)
+
+		d := newPairwiseDiffs(x, y)
+		for k, want := range all {
+			if got := d.selectK(k); got != want {
+				t.Errorf("%v: selectK(%d) = %v, want %v", n, k, got, want)
+			}
+		}
+	}
+}
+
+func TestHodgesLehmann(t *testing.T) {
+	x1 := []float64{1.83, 0.50, 1.62, 2.48, 1.68, 1.88, 1.55, 3.06, 1.30}
+	x2 := []float64{0.878, 0.647, 0.598, 2.05, 1.06, 1.29, 1.06, 3.14, 1.29}
+	// Compare against R's wilcox.test(x1, x2, conf.int=TRUE),
+	// which uses the same exact interval. R's estimate is the
+	// median of the differences.
+	var all []float64
+	for _, a := range x1 {
+		for _, b := range x2 {
+			all = append(all, a-b)
+		}
+	}
+	sort.Float64s(all)
+
+	res, err := HodgesLehmann(x1, x2, 0.95)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !res.Exact {
+		t.Errorf("want exact result")
+	}
+	if want := all[40]; !aeq(res.Shift, want) {
+		t.Errorf("Shift = %v, want %v", res.Shift, want)
+	}
+	// The interval is bounded by the k'th smallest and largest
+	// differences, where k is the largest value such that
+	// Pr[U < k] <= 0.025.
+	d := UDist{N1: 9, N2: 9}
+	k := 0
+	for d.CDF(float64(k)) <= 0.025 {
+		k++
+	}
+	if want := all[k-1]; !aeq(res.Lo, want) {
+		t.Errorf("Lo = %v, want %v", res.Lo, want)
+	}
+	if want := all[81-k]; !aeq(res.Hi, want) {
+		t.Errorf("Hi = %v, want %v", res.Hi, want)
+	}
+	if want := 1 - 2*d.CDF(float64(k-1)); !aeq(res.Confidence, want) {
+		t.Errorf("Confidence = %v, want %v", res.Confidence, want)
+	}
+
+	// With an even number of differences, the estimate is the
+	// average of the middle two.
+	res, _ = HodgesLehmann([]float64{1, 2}, []float64{0, 10}, 0.9)
+	if res.Shift != -3.5 {
+		t.Errorf("Shift = %v, want -3.5", res.Shift)
+	}
+	// The samples are too small for a 90% interval.
+	if !math.IsInf(res.Lo, -1) || !math.IsInf(res.Hi, 1) || res.Confidence != 1 {
+		t.Errorf("want infinite interval, got %+v", res)
+	}
+
+	// The normal approximation should give nearly the same
+	// interval for moderate sample sizes.
+	r := rand.New(rand.NewSource(2))
+	y1, y2 := make([]float64, 40), make([]float64, 50)
+	for i := range y1 {
+		y1[i] = r.NormFloat64() + 1
+	}
+	for i := range y2 {
+		y2[i] = r.NormFloat64()
+	}
+	exact, _ := HodgesLehmann(y1, y2, 0.95)
+	defer func(old int) { MannWhitneyExactLimit = old }(MannWhitneyExactLimit)
+	MannWhitneyExactLimit = 10
+	approx, _ := HodgesLehmann(y1, y2, 0.95)
+	if approx.Exact || exact.Shift != approx.Shift ||
+		!aeqTol(exact.Lo, approx.Lo, 0.02) || !aeqTol(exact.Hi, approx.Hi, 0.02) ||
+		!aeqTol(exact.Confidence, approx.Confidence, 0.002) {
+		t.Errorf("exact %+v and approximate %+v intervals differ", exact, approx)
+	}
+
+	// With no confidence, the interval is just the estimate, using
+	// either the exact or the approximate method.
+	for _, xs := range [][2][]float64{{x1, x2}, {y1, y2}} {
+		res, _ := HodgesLehmann(xs[0], xs[1], 0)
+		if res.Lo != res.Shift || res.Hi != res.Shift || res.Confidence != 0 {
+			t.Errorf("with confidence 0, want [%v, %v] with confidence 0, got %+v", res.Shift, res.Shift, res)
+		}
+	}
+
+	if _, err := HodgesLehmann(nil, x2, 0.95); err != ErrSampleSize {
+		t.Errorf("want ErrSampleSize, got %v", err)
+	}
+}
@@ -0,0 +1,419 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package stats
+
+import (
+	"math"
+	"math/rand"
+	"sort"
+)
+
+// Mixture is a finite mixture of continuous distributions. A value is
+// drawn from a Mixture by first choosing a component with probability
+// proportional to its weight and then drawing a value from that
+// component.
+//
+// Mixture does not implement InvCDF; use the generic InvCDF function.
+type Mixture struct {
+	// Weights are the relative weights of each component. These
+	// need not sum to 1. Weights must be non-negative and at
+	// least one weight must be positive.
+	Weights []float64
+
+	// Components are the component distributions of the
+	// mixture. len(Components) must equal len(Weights).
+	Components []Dist
+}
+
+func (m Mixture) comp(i int) DistCommon {
+	return m.Components[i]
+}
+
+func (m Mixture) PDF(x float64) float64 {
+	return mixtureSum(m.Weights, func(i int) float64 {
+		return m.Components[i].PDF(x)
+	})
+}
+
+func (m Mixture) CDF(x float64) float64 {
+	return mixtureSum(m.Weights, func(i int) float64 {
+		return m.Components[i].CDF(x)
+	})
+}
+
+// Bounds returns the union of the bounds of the components with
+// non-zero weight. If all of the components have finite support,
+// these are exact bounds.
+func (m Mixture) Bounds() (float64, float64) {
+	return mixtureBounds(m.Weights, m.comp)
+}
+
+// Mean returns the mean of the mixture. This requires that every
+// component with non-zero weight implement Mean() float64; otherwise
+// it returns NaN.
+func (m Mixture) Mean() float64 {
+	mean, _ := mixtureMoments(m.Weights, m.comp)
+	return mean
+}
+
+// Variance returns the variance of the mixture. This requires that
+// every component with non-zero weight implement Mean() float64 and
+// Variance() float64; otherwise it returns NaN.
+func (m Mixture) Variance() float64 {
+	_, v := mixtureMoments(m.Weights, m.comp)
+	return v
+}
+
+func (m Mixture) Rand(r *rand.Rand) float64 {
+	return Rand(m.Components[mixturePick(m.Weights, r)])(r)
+}
+
+// DiscreteMixture is a finite mixture of discrete distributions. See
+// Mixture.
+//
+// All components must have the same Step.
+type DiscreteMixture struct {
+	// Weights are the relative weights of each component. These
+	// need not sum to 1. Weights must be non-negative and at
+	// least one weight must be positive.
+	Weights []float64
+
+	// Components are the component distributions of the
+	// mixture. len(Components) must equal len(Weights).
+	Components []DiscreteDist
+}
+
+func (m DiscreteMixture) comp(i int) DistCommon {
+	return m.Components[i]
+}
+
+func (m DiscreteMixture) PMF(x float64) float64 {
+	return mixtureSum(m.Weights, func(i int) float64 {
+		return m.Components[i].PMF(x)
+	})
+}
+
+func (m DiscreteMixture) CDF(x float64) float64 {
+	return mixtureSum(m.Weights, func(i int) float64 {
+		return m.Components[i].CDF(x)
+	})
+}
+
+// Bounds returns the union of the bounds of the components with
+// non-zero weight. If all of the components have finite support,
+// these are exact bounds.
+func (m DiscreteMixture) Bounds() (float64, float64) {
+	return mixtureBounds(m.Weights, m.comp)
+}
+
+// Step returns the step of the components. It panics if the
+// components have different steps.
+func (m DiscreteMixture) Step() float64 {
+	step := m.Components[0].Step()
+	for _, c := range m.Components[1:] {
+		if c.Step() != step {
+			panic("DiscreteMixture components have different steps")
+		}
+	}
+	return step
+}
+
+// Mean returns the mean of the mixture. This requires that every
+// component with non-zero weight implement Mean() float64; otherwise
+// it returns NaN.
+func (m DiscreteMixture) Mean() float64 {
+	mean, _ := mixtureMoments(m.Weights, m.comp)
+	return mean
+}
+
+// Variance returns the variance of the mixture. This requires that
+// every component with non-zero weight implement Mean() float64 and
+// Variance() float64; otherwise it returns NaN.
+func (m DiscreteMixture) Variance() float64 {
+	_, v := mixtureMoments(m.Weights, m.comp)
+	return v
+}
+
+func (m DiscreteMixture) Rand(r *rand.Rand) float64 {
+	return Rand(m.Components[mixturePick(m.Weights, r)])(r)
+}
+
+// mixtureSum returns the weighted average of f(i) over the components
+// with non-zero weight.
+func mixtureSum(weights []float64, f func(i int) float64) float64 {
+	sum, wsum := 0.0, 0.0
+	for i, w := range weights {
+		if w == 0 {
+			continue
+		}
+		sum += w * f(i)
+		wsum += w
+	}
+	return sum / wsum
+}
+
+func mixtureBounds(weights []float64, comp func(i int) DistCommon) (float64, float64) {
+	lo, hi := inf, -inf
+	for i, w := range weights {
+		if w == 0 {
+			continue
+		}
+		l, h := comp(i).Bounds()
+		lo, hi = math.Min(lo, l), math.Max(hi, h)
+	}
+	return lo, hi
+}
+
+// mixtureMoments returns the mean and variance of a mixture using the
+// law of total variance.
+func mixtureMoments(weights []float64, comp func(i int) DistCommon) (mean, variance float64) {
+	type moments interface {
+		Mean() float64
+		Variance() float64
+	}
+	var means, vars []float64
+	for i, w := range weights {
+		if w == 0 {
+			means, vars = append(means, 0), append(vars, 0)
+			continue
+		}
+		c, ok := comp(i).(moments)
+		if !ok {
+			return nan, nan
+		}
+		means, vars = append(means, c.Mean()), append(vars, c.Variance())
+	}
+	mean = mixtureSum(weights, func(i int) float64 { return means[i] })
+	variance = mixtureSum(weights, func(i int) float64 {
+		d := means[i] - mean
+		return vars[i] + d*d
+	})
+	return
+}
+
+// mixturePick returns the index of a component chosen at random with
+// probability proportional to its weight.
+func mixturePick(weights []float64, r *rand.Rand) int {
+	total := 0.0
+	for _, w := range weights {
+		total += w
+	}
+	var u float64
+	if r == nil {
+		u = rand.Float64()
+	} else {
+		u = r.Float64()
+	}
+	u *= total
+	last := 0
+	for i, w := range weights {
+		if w == 0 {
+			continue
+		}
+		if u < w {
+			return i
+		}
+		u -= w
+		last = i
+	}
+	// Rounding error.
+	return last
+}
+
+// GaussianMixtureFit is the result of fitting a mixture of normal
+// distributions to a Sample.
+type GaussianMixtureFit struct {
+	// Mixture is the fitted mixture. Its components are all
+	// NormalDists, in increasing order of their means.
+	Mixture Mixture
+
+	// LogLikelihood is the log-likelihood of the sample under
+	// the fitted mixture.
+	LogLikelihood float64
+
+	// Iterations is the number of EM iterations performed.
+	Iterations int
+
+	// Converged indicates that the log-likelihood converged
+	// before reaching GaussianMixtureMaxIterations.
+	Converged bool
+}
+
+// GaussianMixtureMaxIterations is the maximum number of iterations of
+// the EM algorithm performed by FitGaussianMixture.
+var GaussianMixtureMaxIterations = 1000
+
+// FitGaussianMixture fits a mixture of k normal distributions to s
+// using the expectation-maximization (EM) algorithm [1]. If s is
+// weighted, the weights are treated as frequency weights.
+//
+// EM finds a local maximum of the likelihood, so the result depends
+// on the starting point. FitGaussianMixture starts deterministically
+// by splitting s into k groups of equal weight by value and using the
+// mean and variance of each group. If a heavily weighted value leaves
+// a group empty, that component starts from the mean and variance of
+// all of s with a small weight. To keep the likelihood bounded,
+// the variance of each component is kept at least 1e-6 times the
+// variance of s.
+//
+// This can fail with ErrSampleSize if the total weight of s is less
+// than k, or ErrZeroVariance if all values of s are equal.
+//
+// [1] Dempster, A. P.; Laird, N. M.; Rubin, D. B. (1977). "Maximum
+// Likelihood from Incomplete Data via the EM Algorithm". Journal of
+// the Royal Statistical Society B 39 (1): 1-38.
+func FitGaussianMixture(s Sample, k int) (*GaussianMixtureFit, error) {
+	if k < 1 {
+		panic("FitGaussianMixture requires k >= 1")
+	}
+	if !s.Sorted {
+		s = *s.Copy().Sort()
+	}
+	n := s.Weight()
+	if n < float64(k) || n == 0 {
+		return nil, ErrSampleSize
+	}
+	totalVar := s.Variance() * (n - 1) / n
+	if !(totalVar > 0) {
+		return nil, ErrZeroVariance
+	}
+	minVar := 1e-6 * totalVar
+	weight := func(i int) float64 {
+		if s.Weights == nil {
+			return 1
+		}
+		return s.Weights[i]
+	}
+
+	// Initialize from k groups of equal weight.
+	ws := make([]float64, k)
+	mus := make([]float64, k)
+	vars := make([]float64, k)
+	sums := make([]float64, k)
+	sumSqs := make([]float64, k)
+	cum := 0.0
+	for i, x := range s.Xs {
+		w := weight(i)
+		j := int(float64(k) * (cum + w/2) / n)
+		if j >= k {
+			j = k - 1
+		}
+		cum += w
+		ws[j] += w
+		sums[j] += w * x
+		sumSqs[j] += w * x * x
+	}
+	// A heavily weighted value can leave a group empty. Start
+	// such components from the whole sample with a small weight,
+	// so EM can still move them to where they fit.
+	empty := 0
+	for j := range ws {
+		if ws[j] == 0 {
+			empty++
+			ws[j] = 1e-3 * n / float64(k)
+			mus[j], vars[j] = s.Mean(), totalVar
+			continue
+		}
+		mus[j] = sums[j] / ws[j]
+		vars[j] = math.Max(minVar, sumSqs[j]/ws[j]-mus[j]*mus[j])
+	}
+	if empty > 0 {
+		scale := n / (n + float64(empty)*1e-3*n/float64(k))
+		for j := range ws {
+			ws[j] *= scale
+		}
+	}
+
+	// resp[i*k+j] is the responsibility of component j for
+	// s.Xs[i].
+	resp := make([]float64, len(s.Xs)*k)
+	logPs := make([]float64, k)
+	res := &GaussianMixtureFit{LogLikelihood: -inf}
+	for {
+		res.Iterations++
+
+		// E step.
+		ll := 0.0
+		for i, x := range s.Xs {
+			w := weight(i)
+			if w == 0 {
+				continue
+			}
+			max := -inf
+			for j := range logPs {
+				if ws[j] == 0 {
+					logPs[j] = -inf
+					continue
+				}
+				d := x - mus[j]
+				logPs[j] = math.Log(ws[j]/n) - d*d/(2*vars[j]) - math.Log(2*math.Pi*vars[j])/2
+				max = math.Max(max, logPs[j])
+			}
+			sum := 0.0
+			for _, lp := range logPs {
+				sum += math.Exp(lp - max)
+			}
+			lse := max + math.Log(sum)
+			ll += w * lse
+			for j, lp := range logPs {
+				resp[i*k+j] = math.Exp(lp - lse)
+			}
+		}
+
+		converged := math.Abs(ll-res.LogLikelihood) <= 1e-10*math.Abs(ll)
+		res.LogLikelihood = ll
+		if converged {
+			res.Converged = true
+			break
+		} else if res.Iterations >= GaussianMixtureMaxIterations {
+			break
+		}
+
+		// M step.
+		for j := range ws {
+			ws[j], sums[j] = 0, 0
+		}
+		for i, x := range s.Xs {
+			w := weight(i)
+			for j := range ws {
+				r := w * resp[i*k+j]
+				ws[j] += r
+				sums[j] += r * x
+			}
+		}
+		for j := range ws {
+			if ws[j] > 0 {
+				mus[j] = sums[j] / ws[j]
+			}
+			sumSqs[j] = 0
+		}
+		for i, x := range s.Xs {
+			w := weight(i)
+			for j := range ws {
+				d := x - mus[j]
+				sumSqs[j] += w * resp[i*k+j] * d * d
+			}
+		}
+		for j := range ws {
+			if ws[j] > 0 {
+				vars[j] = math.Max(minVar, sumSqs[j]/ws[j])
+			}
+		}
+	}
+
+	// Construct the mixture with components ordered by mean.
+	order := make([]int, k)
+	for j := range order {
+		order[j] = j
+	}
+	sort.Slice(order, func(a, b int) bool { return mus[order[a]] < mus[order[b]] })
+	res.Mixture.Weights = make([]float64, k)
+	res.Mixture.Components = make([]Dist, k)
+	for i, j := range order {
+		res.Mixture.Weights[i] = ws[j] / n
+		res.Mixture.Components[i] = NormalDist{mus[j], math.Sqrt(vars[j])}
+	}
+	return res, nil
+}
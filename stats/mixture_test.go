@@ -0,0 +1,186 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package stats
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+)
+
+func TestMixture(t *testing.T) {
+	a, b := NormalDist{0, 1}, NormalDist{5, 2}
+	m := Mixture{Weights: []float64{3, 7}, Components: []Dist{a, b}}
+	for _, x := range []float64{-2, 0, 1, 3.5, 5, 9} {
+		if got, want := m.PDF(x), 0.3*a.PDF(x)+0.7*b.PDF(x); !aeq(got, want) {
+			t.Errorf("PDF(%v) = %v, want %v", x, got, want)
+		}
+		if got, want := m.CDF(x), 0.3*a.CDF(x)+0.7*b.CDF(x); !aeq(got, want) {
+			t.Errorf("CDF(%v) = %v, want %v", x, got, want)
+		}
+		if got := InvCDF(m)(m.CDF(x)); !aeqTol(got, x, 1e-6) {
+			t.Errorf("InvCDF(CDF(%v)) = %v", x, got)
+		}
+	}
+	if l, h := m.Bounds(); l != -3 || h != 11 {
+		t.Errorf("want bounds [-3, 11], got [%v, %v]", l, h)
+	}
+	if got := m.Mean(); !aeq(got, 3.5) {
+		t.Errorf("Mean() = %v, want 3.5", got)
+	}
+	if got := m.Variance(); !aeq(got, 8.35) {
+		t.Errorf("Variance() = %v, want 8.35", got)
+	}
+
+	// Zero-weight components are ignored.
+	m0 := Mixture{Weights: []float64{1, 0}, Components: []Dist{a, b}}
+	if l, h := m0.Bounds(); l != -3 || h != 3 {
+		t.Errorf("want bounds [-3, 3], got [%v, %v]", l, h)
+	}
+
+	// Components without moments.
+	mk := Mixture{Weights: []float64{1, 1}, Components: []Dist{a, &KDE{Sample: Sample{Xs: []float64{1, 2}}}}}
+	if got := mk.Mean(); !math.IsNaN(got) {
+		t.Errorf("Mean() with KDE component = %v, want NaN", got)
+	}
+}
+
+func TestMixtureRand(t *testing.T) {
+	m := Mixture{Weights: []float64{3, 7}, Components: []Dist{NormalDist{0, 1}, NormalDist{5, 2}}}
+	r := rand.New(rand.NewSource(1))
+	var xs []float64
+	for i := 0; i < 100000; i++ {
+		xs = append(xs, m.Rand(r))
+	}
+	if got := Mean(xs); !aeqTol(got, 3.5, 0.05) {
+		t.Errorf("mean of random values = %v, want ~3.5", got)
+	}
+	if got := Variance(xs); !aeqTol(got, 8.35, 0.1) {
+		t.Errorf("variance of random values = %v, want ~8.35", got)
+	}
+}
+
+func TestDiscreteMixture(t *testing.T) {
+	a, b := BinomialDist{N: 4, P: 0.5}, BinomialDist{N: 10, P: 0.8}
+	m := DiscreteMixture{Weights: []float64{0.5, 0.5}, Components: []DiscreteDist{a, b}}
+	testDiscreteCDF(t, "DiscreteMixture", m)
+	for _, x := range []float64{0, 2, 4.5, 8} {
+		if got, want := m.PMF(x), (a.PMF(x)+b.PMF(x))/2; !aeq(got, want) {
+			t.Errorf("PMF(%v) = %v, want %v", x, got, want)
+		}
+	}
+	if l, h := m.Bounds(); l != 0 || h != 10 {
+		t.Errorf("want bounds [0, 10], got [%v, %v]", l, h)
+	}
+	if got := m.Step(); got != 1 {
+		t.Errorf("Step() = %v, want 1", got)
+	}
+	if got, want := m.Mean(), (2+8)/2.0; !aeq(got, want) {
+		t.Errorf("Mean() = %v, want %v", got, want)
+	}
+	// (1 + 1.6)/2 + 3²
+	if got := m.Variance(); !aeq(got, 10.3) {
+		t.Errorf("Variance() = %v, want 10.3", got)
+	}
+	r := rand.New(rand.NewSource(1))
+	for i := 0; i < 100; i++ {
+		if x := m.Rand(r); x != math.Floor(x) || x < 0 || x > 10 {
+			t.Fatalf("Rand() returned %v", x)
+		}
+	}
+}
+
+func TestFitGaussianMixture(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	truth := Mixture{Weights: []float64{0.4, 0.6}, Components: []Dist{NormalDist{10, 1}, NormalDist{20, 2}}}
+	var xs []float64
+	for i := 0; i < 5000; i++ {
+		xs = append(xs, truth.Rand(r))
+	}
+	fit, err := FitGaussianMixture(Sample{Xs: xs}, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !fit.Converged {
+		t.Errorf("EM did not converge after %d iterations", fit.Iterations)
+	}
+	for i, want := range truth.Components {
+		got := fit.Mixture.Components[i].(NormalDist)
+		want := want.(NormalDist)
+		if !aeqTol(fit.Mixture.Weights[i], truth.Weights[i], 0.03) ||
+			!aeqTol(got.Mu, want.Mu, 0.1) || !aeqTol(got.Sigma, want.Sigma, 0.1) {
+			t.Errorf("component %d: want %v@%v, got %v@%v", i, truth.Weights[i], want, fit.Mixture.Weights[i], got)
+		}
+	}
+	ll := 0.0
+	for _, x := range xs {
+		ll += math.Log(fit.Mixture.PDF(x))
+	}
+	if !aeq(ll, fit.LogLikelihood) {
+		t.Errorf("LogLikelihood = %v, want %v", fit.LogLikelihood, ll)
+	}
+
+	// A single component is the maximum likelihood normal fit.
+	fit1, err := FitGaussianMixture(Sample{Xs: xs}, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	n := float64(len(xs))
+	got := fit1.Mixture.Components[0].(NormalDist)
+	if !aeq(got.Mu, Mean(xs)) || !aeq(got.Sigma, math.Sqrt(Variance(xs)*(n-1)/n)) {
+		t.Errorf("single component fit = %v", got)
+	}
+}
+
+func TestFitGaussianMixtureWeighted(t *testing.T) {
+	ws := Sample{Xs: []float64{1, 1.5, 2, 8, 9, 10}, Weights: []float64{2, 1, 3, 1, 2, 1}}
+	rep := Sample{Xs: []float64{1, 1, 1.5, 2, 2, 2, 8, 9, 9, 10}}
+	fw, err := FitGaussianMixture(ws, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	fr, err := FitGaussianMixture(rep, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i := range fw.Mixture.Components {
+		cw := fw.Mixture.Components[i].(NormalDist)
+		cr := fr.Mixture.Components[i].(NormalDist)
+		if !aeq(fw.Mixture.Weights[i], fr.Mixture.Weights[i]) || !aeq(cw.Mu, cr.Mu) || !aeq(cw.Sigma, cr.Sigma) {
+			t.Errorf("component %d: weighted %v@%v, replicated %v@%v", i,
+				fw.Mixture.Weights[i], cw, fr.Mixture.Weights[i], cr)
+		}
+	}
+	if !aeq(fw.LogLikelihood, fr.LogLikelihood) {
+		t.Errorf("weighted log-likelihood %v, replicated %v", fw.LogLikelihood, fr.LogLikelihood)
+	}
+
+	// A heavy value at 0 leaves the first initial group empty and
+	// puts the clusters at 10 and 20 in the same group. The empty
+	// component must still be able to take one of them.
+	r := rand.New(rand.NewSource(1))
+	heavy := Sample{Xs: []float64{0}, Weights: []float64{200}}
+	for i := 0; i < 20; i++ {
+		heavy.Xs = append(heavy.Xs, 10+r.NormFloat64(), 20+r.NormFloat64())
+		heavy.Weights = append(heavy.Weights, 1, 1)
+	}
+	fh, err := FitGaussianMixture(heavy, 3)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i, want := range []float64{0, 10, 20} {
+		c := fh.Mixture.Components[i].(NormalDist)
+		if !aeqTol(c.Mu, want, 1) || !(fh.Mixture.Weights[i] > 0.05) {
+			t.Errorf("component %d: got %v@%v, want mean ≈%v", i, fh.Mixture.Weights[i], c, want)
+		}
+	}
+
+	if _, err := FitGaussianMixture(Sample{Xs: []float64{1, 2}}, 3); err != ErrSampleSize {
+		t.Errorf("want ErrSampleSize, got %v", err)
+	}
+	if _, err := FitGaussianMixture(Sample{Xs: []float64{1, 1, 1}}, 2); err != ErrZeroVariance {
+		t.Errorf("want ErrZeroVariance, got %v", err)
+	}
+}
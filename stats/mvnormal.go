@@ -0,0 +1,165 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package stats
+
+import (
+	"math"
+	"math/rand"
+
+	"gonum.org/v1/gonum/mat"
+)
+
+// MultivariateNormal is a multivariate normal (Gaussian) distribution
+// with a mean vector and a covariance matrix. Use NewMultivariateNormal
+// to construct a MultivariateNormal.
+//
+// Since its values are vectors, MultivariateNormal does not implement
+// Dist. However, its marginal and conditional distributions along a
+// single dimension are NormalDists.
+type MultivariateNormal struct {
+	mu    []float64
+	sigma *mat.SymDense
+
+	// l is the lower-triangular Cholesky factor of sigma, so
+	// sigma = l lᵀ.
+	l *mat.TriDense
+
+	// logNorm is the logarithm of the normalizing constant of the
+	// PDF, -(k log(2π) + log(det(sigma))) / 2.
+	logNorm float64
+}
+
+// NewMultivariateNormal returns a multivariate normal distribution
+// with mean vector mu and covariance matrix sigma. It panics if the
+// dimensions of sigma do not match len(mu). If sigma is not positive
+// definite, it returns ErrNotPositiveDefinite.
+//
+// NewMultivariateNormal copies mu and sigma, so the caller may
+// modify them afterward.
+func NewMultivariateNormal(mu []float64, sigma mat.Symmetric) (*MultivariateNormal, error) {
+	k := len(mu)
+	if sigma.SymmetricDim() != k {
+		panic("covariance matrix dimensions do not match len(mu)")
+	}
+	if k == 0 {
+		panic("multivariate normal must have at least one dimension")
+	}
+
+	var chol mat.Cholesky
+	if !chol.Factorize(sigma) {
+		return nil, ErrNotPositiveDefinite
+	}
+	l := new(mat.TriDense)
+	chol.LTo(l)
+	sigmaCopy := mat.NewSymDense(k, nil)
+	sigmaCopy.CopySym(sigma)
+
+	return &MultivariateNormal{
+		mu:      append([]float64(nil), mu...),
+		sigma:   sigmaCopy,
+		l:       l,
+		logNorm: -(float64(k)*math.Log(2*math.Pi) + chol.LogDet()) / 2,
+	}, nil
+}
+
+// Dim returns the number of dimensions of d.
+func (d *MultivariateNormal) Dim() int {
+	return len(d.mu)
+}
+
+// Mean returns a copy of the mean vector of d.
+func (d *MultivariateNormal) Mean() []float64 {
+	return append([]float64(nil), d.mu...)
+}
+
+// Covariance returns a copy of the covariance matrix of d.
+func (d *MultivariateNormal) Covariance() *mat.SymDense {
+	sigma := mat.NewSymDense(d.Dim(), nil)
+	sigma.CopySym(d.sigma)
+	return sigma
+}
+
+// LogPDF returns the logarithm of the probability density of d at x.
+// It panics if len(x) != d.Dim().
+func (d *MultivariateNormal) LogPDF(x []float64) float64 {
+	if len(x) != d.Dim() {
+		panic("len(x) != Dim()")
+	}
+	// The exponent of the PDF is -(x-μ)ᵀΣ⁻¹(x-μ)/2. Since
+	// Σ = LLᵀ, this is -|z|²/2 where z = L⁻¹(x-μ).
+	z := mat.NewVecDense(d.Dim(), nil)
+	for i, xi := range x {
+		z.SetVec(i, xi-d.mu[i])
+	}
+	// The factorization succeeded, so L is non-singular. An
+	// error here only reports that it is ill-conditioned, and z
+	// is still the best available solution.
+	_ = z.SolveVec(d.l, z)
+	return d.logNorm - mat.Dot(z, z)/2
+}
+
+// PDF returns the probability density of d at x. It panics if
+// len(x) != d.Dim().
+func (d *MultivariateNormal) PDF(x []float64) float64 {
+	return math.Exp(d.LogPDF(x))
+}
+
+// Rand returns a random vector drawn from d. It computes μ + Lz,
+// where L is the Cholesky factor of the covariance matrix and z is a
+// vector of independent standard normal values.
+//
+// If r is nil, Rand uses the default source from math/rand.
+func (d *MultivariateNormal) Rand(r *rand.Rand) []float64 {
+	k := d.Dim()
+	z := mat.NewVecDense(k, nil)
+	for i := 0; i < k; i++ {
+		z.SetVec(i, StdNormal.Rand(r))
+	}
+	z.MulVec(d.l, z)
+	x := make([]float64, k)
+	for i := range x {
+		x[i] = d.mu[i] + z.AtVec(i)
+	}
+	return x
+}
+
+// Marginal returns the marginal distribution of dimension i of d.
+func (d *MultivariateNormal) Marginal(i int) NormalDist {
+	return NormalDist{d.mu[i], math.Sqrt(d.sigma.At(i, i))}
+}
+
+// Conditional returns the distribution of dimension i of d given that
+// every other dimension j has the value x[j]. x[i] is ignored. It
+// panics if len(x) != d.Dim().
+func (d *MultivariateNormal) Conditional(i int, x []float64) NormalDist {
+	k := d.Dim()
+	if len(x) != k {
+		panic("len(x) != Dim()")
+	}
+	if k == 1 {
+		return d.Marginal(0)
+	}
+	// Let P = Σ⁻¹ be the precision matrix. Then the conditional
+	// distribution has variance 1/Pᵢᵢ and mean
+	//
+	//     μᵢ - ∑_{j≠i} Pᵢⱼ(xⱼ - μⱼ) / Pᵢᵢ.
+	//
+	// Row i of P is the solution p of Σp = eᵢ, which we find
+	// using the Cholesky factor by solving Ly = eᵢ and Lᵀp = y.
+	p := mat.NewVecDense(k, nil)
+	p.SetVec(i, 1)
+	// As in LogPDF, errors only report ill-conditioning.
+	_ = p.SolveVec(d.l, p)
+	_ = p.SolveVec(d.l.T(), p)
+
+	pii := p.AtVec(i)
+	mean := d.mu[i]
+	for j := 0; j < k; j++ {
+		if j != i {
+			mean -= p.AtVec(j) * (x[j] - d.mu[j]) / pii
+		}
+	}
+	return NormalDist{mean, math.Sqrt(1 / pii)}
+}
@@ -0,0 +1,91 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package stats
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+
+	"gonum.org/v1/gonum/mat"
+)
+
+func TestMultivariateNormal(t *testing.T) {
+	const s1, s2, rho = 2, 1, 0.6
+	mu := []float64{1, -2}
+	sigma := mat.NewSymDense(2, []float64{
+		s1 * s1, rho * s1 * s2,
+		rho * s1 * s2, s2 * s2,
+	})
+	d, err := NewMultivariateNormal(mu, sigma)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Modifying the arguments must not affect d.
+	mu[0] = 100
+	sigma.SetSym(0, 0, 100)
+
+	// Bivariate normal density.
+	pdf := func(x, y float64) float64 {
+		zx, zy := (x-1)/s1, (y+2)/s2
+		q := (zx*zx - 2*rho*zx*zy + zy*zy) / (1 - rho*rho)
+		return math.Exp(-q/2) / (2 * math.Pi * s1 * s2 * math.Sqrt(1-rho*rho))
+	}
+	for _, x := range [][]float64{{1, -2}, {0, 0}, {3, -1}, {-2, -4}} {
+		want := pdf(x[0], x[1])
+		if got := d.PDF(x); !aeq(got, want) {
+			t.Errorf("PDF(%v) = %v, want %v", x, got, want)
+		}
+		if got := d.LogPDF(x); !aeq(got, math.Log(want)) {
+			t.Errorf("LogPDF(%v) = %v, want %v", x, got, math.Log(want))
+		}
+	}
+
+	if got, want := d.Marginal(0), (NormalDist{1, s1}); !aeq(got.Mu, want.Mu) || !aeq(got.Sigma, want.Sigma) {
+		t.Errorf("Marginal(0) = %+v, want %+v", got, want)
+	}
+	if got, want := d.Marginal(1), (NormalDist{-2, s2}); !aeq(got.Mu, want.Mu) || !aeq(got.Sigma, want.Sigma) {
+		t.Errorf("Marginal(1) = %+v, want %+v", got, want)
+	}
+
+	// X₀ | X₁=y ~ N(μ₀ + ρσ₀/σ₁ (y-μ₁), σ₀²(1-ρ²)).
+	got := d.Conditional(0, []float64{0, -1})
+	want := NormalDist{1 + rho*s1/s2*1, s1 * math.Sqrt(1-rho*rho)}
+	if !aeq(got.Mu, want.Mu) || !aeq(got.Sigma, want.Sigma) {
+		t.Errorf("Conditional(0, y=-1) = %+v, want %+v", got, want)
+	}
+	got = d.Conditional(1, []float64{3, 0})
+	want = NormalDist{-2 + rho*s2/s1*2, s2 * math.Sqrt(1-rho*rho)}
+	if !aeq(got.Mu, want.Mu) || !aeq(got.Sigma, want.Sigma) {
+		t.Errorf("Conditional(1, x=3) = %+v, want %+v", got, want)
+	}
+
+	// Check the moments of random vectors.
+	r := rand.New(rand.NewSource(1))
+	const n = 100000
+	var xs, ys Sample
+	for i := 0; i < n; i++ {
+		v := d.Rand(r)
+		xs.Xs = append(xs.Xs, v[0])
+		ys.Xs = append(ys.Xs, v[1])
+	}
+	if m := xs.Mean(); math.Abs(m-1) > 0.05 {
+		t.Errorf("mean of Rand()[0] = %v, want 1", m)
+	}
+	if m := ys.Mean(); math.Abs(m+2) > 0.05 {
+		t.Errorf("mean of Rand()[1] = %v, want -2", m)
+	}
+	if sd := xs.StdDev(); math.Abs(sd-s1) > 0.05 {
+		t.Errorf("stddev of Rand()[0] = %v, want %v", sd, s1)
+	}
+	if c := (BivariateSample{Xs: xs.Xs, Ys: ys.Xs}).Pearson(); math.Abs(c-rho) > 0.02 {
+		t.Errorf("correlation of Rand() = %v, want %v", c, rho)
+	}
+
+	_, err = NewMultivariateNormal([]float64{0, 0}, mat.NewSymDense(2, []float64{1, 2, 2, 1}))
+	if err != ErrNotPositiveDefinite {
+		t.Errorf("want ErrNotPositiveDefinite, got %v", err)
+	}
+}
@@ -0,0 +1,344 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package stats
+
+import (
+	"math"
+
+	"github.com/aclements/go-moremath/mathx"
+)
+
+// noncentralBoundsTail is the probability in each tail outside the
+// Bounds of the noncentral distributions.
+const noncentralBoundsTail = 1e-4
+
+// A NoncentralTDist is a noncentral t-distribution with V degrees of
+// freedom and noncentrality parameter Delta. This is the distribution
+// of (Z + Delta) / √(X/V), where Z is a standard normal random
+// variable and X is an independent chi-squared random variable with V
+// degrees of freedom.
+//
+// This is the distribution of the t statistic of a t-test when the
+// alternative hypothesis is true, so it is used for computing the
+// power of t-tests. If Delta is 0, this is the same as TDist{V}.
+type NoncentralTDist struct {
+	V, Delta float64
+}
+
+func (d NoncentralTDist) PDF(x float64) float64 {
+	if x < 0 {
+		return NoncentralTDist{d.V, -d.Delta}.PDF(-x)
+	} else if x == 0 {
+		return math.Exp(lgamma((d.V+1)/2)-lgamma(d.V/2)-d.Delta*d.Delta/2) /
+			math.Sqrt(math.Pi*d.V)
+	}
+	// Differentiate the series in CDF term by term.
+	y, ly, lyc := d.betaArg(x)
+	sum := poissonSum(d.Delta*d.Delta/2, func(j float64) float64 {
+		return betaPDF(y, ly, lyc, j+0.5, d.V/2) +
+			d.qCoef(j)*betaPDF(y, ly, lyc, j+1, d.V/2)
+	})
+	return sum * x * d.V / ((x*x + d.V) * (x*x + d.V))
+}
+
+// betaArg returns x²/(x²+V) and its logarithm and the logarithm of its
+// complement.
+func (d NoncentralTDist) betaArg(x float64) (y, ly, lyc float64) {
+	x2 := x * x
+	return x2 / (x2 + d.V), math.Log(x2 / (x2 + d.V)), math.Log(d.V / (x2 + d.V))
+}
+
+// qCoef returns the ratio of the weight of the j'th odd term to the
+// j'th even term of the series in CDF.
+func (d NoncentralTDist) qCoef(j float64) float64 {
+	return d.Delta / math.Sqrt2 * math.Exp(lgamma(j+1)-lgamma(j+1.5))
+}
+
+func (d NoncentralTDist) CDF(x float64) float64 {
+	if math.IsNaN(x) {
+		return nan
+	} else if x < 0 {
+		return 1 - NoncentralTDist{d.V, -d.Delta}.CDF(-x)
+	}
+	// This is algorithm AS 243 (Lenth, 1989), which expresses the
+	// CDF as a Poisson mixture of incomplete beta functions.
+	y, _, _ := d.betaArg(x)
+	if math.IsInf(x, 1) {
+		y = 1
+	}
+	sum := poissonSum(d.Delta*d.Delta/2, func(j float64) float64 {
+		return mathx.BetaInc(y, j+0.5, d.V/2) +
+			d.qCoef(j)*mathx.BetaInc(y, j+1, d.V/2)
+	})
+	return math.Max(0, math.Min(1, StdNormal.CDF(-d.Delta)+sum/2))
+}
+
+func (d NoncentralTDist) InvCDF(y float64) float64 {
+	if y < 0 || y > 1 || math.IsNaN(y) {
+		return nan
+	} else if y == 0 {
+		return -inf
+	} else if y == 1 {
+		return inf
+	}
+	return invCDFSearch(d.CDF, y, d.Delta, 1, -inf)
+}
+
+func (d NoncentralTDist) Bounds() (float64, float64) {
+	return d.InvCDF(noncentralBoundsTail), d.InvCDF(1 - noncentralBoundsTail)
+}
+
+// Mean returns the mean of d, which is only defined if V > 1.
+func (d NoncentralTDist) Mean() float64 {
+	if d.V <= 1 {
+		return nan
+	}
+	return d.Delta * math.Sqrt(d.V/2) * math.Exp(lgamma((d.V-1)/2)-lgamma(d.V/2))
+}
+
+// Variance returns the variance of d, which is only defined if V > 2.
+func (d NoncentralTDist) Variance() float64 {
+	if d.V <= 2 {
+		return nan
+	}
+	m := d.Mean()
+	return d.V*(1+d.Delta*d.Delta)/(d.V-2) - m*m
+}
+
+// A NoncentralChiSquaredDist is a noncentral chi-squared distribution
+// with K degrees of freedom and noncentrality parameter Lambda. This
+// is the distribution of the sum of the squares of K independent
+// normal random variables with unit variance and means μᵢ, where
+// Lambda = ∑ μᵢ².
+type NoncentralChiSquaredDist struct {
+	K, Lambda float64
+}
+
+func (d NoncentralChiSquaredDist) PDF(x float64) float64 {
+	if x < 0 {
+		return 0
+	} else if x == 0 {
+		// Only the j=0 term contributes.
+		switch {
+		case d.K < 2:
+			return inf
+		case d.K == 2:
+			return math.Exp(-d.Lambda/2) / 2
+		}
+		return 0
+	}
+	lx := math.Log(x / 2)
+	return poissonSum(d.Lambda/2, func(j float64) float64 {
+		a := d.K/2 + j
+		return math.Exp((a-1)*lx-x/2-lgamma(a)) / 2
+	})
+}
+
+func (d NoncentralChiSquaredDist) CDF(x float64) float64 {
+	if math.IsNaN(x) {
+		return nan
+	} else if x <= 0 {
+		return 0
+	} else if math.IsInf(x, 1) {
+		return 1
+	}
+	// Mix the CDFs of central chi-squared distributions with K+2j
+	// degrees of freedom, weighted by a Poisson distribution with
+	// mean Lambda/2.
+	return math.Min(1, poissonSum(d.Lambda/2, func(j float64) float64 {
+		return mathx.GammaInc(d.K/2+j, x/2)
+	}))
+}
+
+func (d NoncentralChiSquaredDist) InvCDF(y float64) float64 {
+	if y < 0 || y > 1 || math.IsNaN(y) {
+		return nan
+	} else if y == 0 {
+		return 0
+	} else if y == 1 {
+		return inf
+	}
+	return invCDFSearch(d.CDF, y, d.Mean(), math.Sqrt(d.Variance()), 0)
+}
+
+// Bounds returns the bounds of d. The lower bound, 0, is exact.
+func (d NoncentralChiSquaredDist) Bounds() (float64, float64) {
+	return 0, d.InvCDF(1 - noncentralBoundsTail)
+}
+
+func (d NoncentralChiSquaredDist) Mean() float64 {
+	return d.K + d.Lambda
+}
+
+func (d NoncentralChiSquaredDist) Variance() float64 {
+	return 2 * (d.K + 2*d.Lambda)
+}
+
+// A NoncentralFDist is a noncentral F-distribution with D1 and D2
+// degrees of freedom and noncentrality parameter Lambda. This is the
+// distribution of (X₁/D1) / (X₂/D2), where X₁ is a noncentral
+// chi-squared random variable with D1 degrees of freedom and
+// noncentrality Lambda, and X₂ is an independent chi-squared random
+// variable with D2 degrees of freedom.
+//
+// This is the distribution of the F statistic of an analysis of
+// variance when the alternative hypothesis is true, so it is used
+// for computing the power of F-tests. If Lambda is 0, this is the
+// central F-distribution.
+type NoncentralFDist struct {
+	D1, D2, Lambda float64
+}
+
+// betaArg returns D1 x/(D1 x + D2) and its logarithm and the
+// logarithm of its complement.
+func (d NoncentralFDist) betaArg(x float64) (y, ly, lyc float64) {
+	den := d.D1*x + d.D2
+	return d.D1 * x / den, math.Log(d.D1 * x / den), math.Log(d.D2 / den)
+}
+
+func (d NoncentralFDist) PDF(x float64) float64 {
+	if x < 0 {
+		return 0
+	}
+	y, ly, lyc := d.betaArg(x)
+	sum := poissonSum(d.Lambda/2, func(j float64) float64 {
+		return betaPDF(y, ly, lyc, d.D1/2+j, d.D2/2)
+	})
+	den := d.D1*x + d.D2
+	return sum * d.D1 * d.D2 / (den * den)
+}
+
+func (d NoncentralFDist) CDF(x float64) float64 {
+	if math.IsNaN(x) {
+		return nan
+	} else if x <= 0 {
+		return 0
+	} else if math.IsInf(x, 1) {
+		return 1
+	}
+	// Mix the CDFs of central F distributions with D1+2j and D2
+	// degrees of freedom, weighted by a Poisson distribution with
+	// mean Lambda/2.
+	y, _, _ := d.betaArg(x)
+	return math.Min(1, poissonSum(d.Lambda/2, func(j float64) float64 {
+		return mathx.BetaInc(y, d.D1/2+j, d.D2/2)
+	}))
+}
+
+func (d NoncentralFDist) InvCDF(y float64) float64 {
+	if y < 0 || y > 1 || math.IsNaN(y) {
+		return nan
+	} else if y == 0 {
+		return 0
+	} else if y == 1 {
+		return inf
+	}
+	// Start from the mean of the numerator, which is defined even
+	// if the mean of d is not.
+	return invCDFSearch(d.CDF, y, 1+d.Lambda/d.D1, 1, 0)
+}
+
+// Bounds returns the bounds of d. The lower bound, 0, is exact.
+func (d NoncentralFDist) Bounds() (float64, float64) {
+	return 0, d.InvCDF(1 - noncentralBoundsTail)
+}
+
+// Mean returns the mean of d, which is only defined if D2 > 2.
+func (d NoncentralFDist) Mean() float64 {
+	if d.D2 <= 2 {
+		return nan
+	}
+	return d.D2 * (d.D1 + d.Lambda) / (d.D1 * (d.D2 - 2))
+}
+
+// Variance returns the variance of d, which is only defined if
+// D2 > 4.
+func (d NoncentralFDist) Variance() float64 {
+	if d.D2 <= 4 {
+		return nan
+	}
+	r := d.D2 / d.D1
+	n := (d.D1+d.Lambda)*(d.D1+d.Lambda) + (d.D1+2*d.Lambda)*(d.D2-2)
+	return 2 * r * r * n / ((d.D2 - 2) * (d.D2 - 2) * (d.D2 - 4))
+}
+
+// poissonSum returns ∑ⱼ Pr[J = j] f(j) where J is Poisson distributed
+// with mean mu. It sums outward from the mode of J until the terms
+// are negligible, so each term must be a unimodal function of j.
+func poissonSum(mu float64, f func(j float64) float64) float64 {
+	const eps = 1e-17
+	if mu == 0 {
+		return f(0)
+	}
+	mode := math.Floor(mu)
+	wMode := math.Exp(-mu + mode*math.Log(mu) - lgamma(mode+1))
+
+	sum := 0.0
+	prev := inf
+	for j, w := mode, wMode; w > 0; j++ {
+		t := w * f(j)
+		sum += t
+		if math.Abs(t) <= eps*math.Abs(sum) && math.Abs(t) <= prev {
+			break
+		}
+		prev = math.Abs(t)
+		w *= mu / (j + 1)
+	}
+	prev = inf
+	for j, w := mode-1, wMode; j >= 0; j-- {
+		w *= (j + 1) / mu
+		if w == 0 {
+			break
+		}
+		t := w * f(j)
+		sum += t
+		if math.Abs(t) <= eps*math.Abs(sum) && math.Abs(t) <= prev {
+			break
+		}
+		prev = math.Abs(t)
+	}
+	return sum
+}
+
+// betaPDF returns the density of the beta distribution with
+// parameters a and b at y, given log(y) and log(1-y).
+func betaPDF(y, ly, lyc, a, b float64) float64 {
+	if y == 0 {
+		switch {
+		case a < 1:
+			return inf
+		case a == 1:
+			return b
+		}
+		return 0
+	}
+	return math.Exp((a-1)*ly + (b-1)*lyc - lgamma(a) - lgamma(b) + lgamma(a+b))
+}
+
+// invCDFSearch returns x such that cdf(x) = y. It searches outward
+// from x0 in steps of at least scale to bracket x, but not below lo,
+// and then bisects to the precision of cdf.
+func invCDFSearch(cdf func(float64) float64, y, x0, scale, lo float64) float64 {
+	if !(scale > 0) {
+		scale = 1
+	}
+	x1, x2 := x0, x0
+	for delta := scale; cdf(x1) >= y; delta *= 2 {
+		x2, x1 = x1, math.Max(lo, x1-delta)
+		if x1 == lo {
+			break
+		}
+	}
+	for delta := scale; cdf(x2) < y; delta *= 2 {
+		x1, x2 = x2, x2+delta
+		if math.IsInf(x2, 1) {
+			return inf
+		}
+	}
+	_, x := bisectBool(func(x float64) bool {
+		return cdf(x) < y
+	}, x1, x2, 0)
+	return x
+}
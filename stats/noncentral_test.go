@@ -0,0 +1,164 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package stats
+
+import (
+	"fmt"
+	"math"
+	"testing"
+
+	"github.com/aclements/go-moremath/mathx"
+)
+
+// testPDFIntegral checks that the PDF of dist integrates to its CDF
+// between pairs of points in xs.
+func testPDFIntegral(t *testing.T, name string, dist Dist, xs []float64) {
+	t.Helper()
+	for i := 1; i < len(xs); i++ {
+		a, b := xs[i-1], xs[i]
+		got := adaptiveSimpson(dist.PDF, a, b, 1e-12)
+		if want := dist.CDF(b) - dist.CDF(a); !aeqTol(got, want, 1e-9) {
+			t.Errorf("%s: ∫PDF over [%v, %v] = %v, want %v", name, a, b, got, want)
+		}
+	}
+}
+
+// testInvCDFRoundTrip checks that dist.InvCDF inverts dist.CDF for a
+// distribution with support [0, ∞).
+func testInvCDFRoundTrip(t *testing.T, dist Dist) {
+	t.Helper()
+	inv := InvCDF(dist)
+	testFunc(t, fmt.Sprintf("InvCDF(%+v)", dist), inv, map[float64]float64{
+		-0.01: nan, 0: 0, 1: inf, 1.01: nan,
+	})
+	for _, y := range []float64{1e-6, 0.01, 0.1, 0.5, 0.9, 0.99, 0.999999} {
+		if got := dist.CDF(inv(y)); !aeqTol(got, y, 1e-12) {
+			t.Errorf("CDF(InvCDF(%+v)(%v)) = %v", dist, y, got)
+		}
+	}
+}
+
+func TestNoncentralT(t *testing.T) {
+	// Computed by numerical integration of
+	// ∫ Φ(x√(v/V) - Delta) f_χ²(v; V) dv.
+	for _, test := range []struct {
+		x, v, delta, want float64
+	}{
+		{1, 5, 1, 0.480926141242086},
+		{2.5, 10, 2, 0.6480249588698825},
+		{-1, 3, 0.5, 0.0921212098143564},
+		{3, 4, -1, 0.9978199145162421},
+		{0.5, 20, 3, 0.006236991303444177},
+	} {
+		d := NoncentralTDist{test.v, test.delta}
+		if got := d.CDF(test.x); !aeqTol(got, test.want, 1e-10) {
+			t.Errorf("%+v.CDF(%v) = %v, want %v", d, test.x, got, test.want)
+		}
+	}
+
+	// With no noncentrality, this is a t-distribution.
+	for _, x := range []float64{-3, -0.5, 0, 0.5, 3} {
+		d, td := NoncentralTDist{5, 0}, TDist{5}
+		if got, want := d.PDF(x), td.PDF(x); !aeq(got, want) {
+			t.Errorf("%+v.PDF(%v) = %v, want %v", d, x, got, want)
+		}
+		if got, want := d.CDF(x), td.CDF(x); !aeq(got, want) {
+			t.Errorf("%+v.CDF(%v) = %v, want %v", d, x, got, want)
+		}
+	}
+
+	for _, d := range []NoncentralTDist{{5, 1}, {10, -2}, {3, 4}} {
+		if got, want := d.CDF(0), StdNormal.CDF(-d.Delta); !aeq(got, want) {
+			t.Errorf("%+v.CDF(0) = %v, want %v", d, got, want)
+		}
+		testPDFIntegral(t, "NoncentralTDist", d, []float64{-6, -2, -0.5, 0, 0.5, 2, 6, 12})
+		testInvCDF(t, d, false)
+		if got := DistMean(struct{ Dist }{d}); d.V > 1 && !aeqTol(got, d.Mean(), 1e-5) {
+			t.Errorf("%+v: numerical mean %v, want %v", d, got, d.Mean())
+		}
+	}
+}
+
+func TestNoncentralChiSquared(t *testing.T) {
+	// For K=1, this is the distribution of (Z+√Lambda)².
+	d1 := NoncentralChiSquaredDist{1, 2}
+	for _, x := range []float64{0.1, 1, 2, 5, 10} {
+		want := StdNormal.CDF(math.Sqrt(x)-math.Sqrt2) - StdNormal.CDF(-math.Sqrt(x)-math.Sqrt2)
+		if got := d1.CDF(x); !aeq(got, want) {
+			t.Errorf("%+v.CDF(%v) = %v, want %v", d1, x, got, want)
+		}
+	}
+
+	// Computed by numerically convolving K=1 with a central
+	// chi-squared with 2 degrees of freedom.
+	d3 := NoncentralChiSquaredDist{3, 2}
+	if got := d3.CDF(4); !aeqTol(got, 0.4838813583880806, 1e-10) {
+		t.Errorf("%+v.CDF(4) = %v, want 0.4838813583880806", d3, got)
+	}
+	if got := d3.PDF(4); !aeqTol(got, 0.11839464506363408, 1e-10) {
+		t.Errorf("%+v.PDF(4) = %v, want 0.11839464506363408", d3, got)
+	}
+	if got := (NoncentralChiSquaredDist{3, 5}).CDF(10); !aeqTol(got, 0.7066486477774632, 1e-10) {
+		t.Errorf("CDF(10) = %v, want 0.7066486477774632", got)
+	}
+
+	// With no noncentrality, this is a chi-squared distribution.
+	for _, x := range []float64{0.5, 3, 10} {
+		if got, want := (NoncentralChiSquaredDist{4, 0}).CDF(x), mathx.GammaInc(2, x/2); !aeq(got, want) {
+			t.Errorf("CDF(%v) = %v, want %v", x, got, want)
+		}
+	}
+
+	for _, d := range []NoncentralChiSquaredDist{d1, d3, {2, 0.5}, {10, 30}} {
+		testPDFIntegral(t, "NoncentralChiSquaredDist", d, []float64{0.01, 0.5, 2, 5, 10, 20, 40, 80})
+		testInvCDFRoundTrip(t, d)
+		if d.K < 2 {
+			// The PDF has a pole at 0, which defeats
+			// numerical integration.
+			continue
+		}
+		if got := DistVariance(struct{ Dist }{d}); !aeqTol(got, d.Variance(), 1e-5) {
+			t.Errorf("%+v: numerical variance %v, want %v", d, got, d.Variance())
+		}
+	}
+	if got := (NoncentralChiSquaredDist{2, 1}).PDF(0); !aeq(got, math.Exp(-0.5)/2) {
+		t.Errorf("PDF(0) = %v, want %v", got, math.Exp(-0.5)/2)
+	}
+}
+
+func TestNoncentralF(t *testing.T) {
+	// If T is noncentral t with V degrees of freedom and
+	// noncentrality Delta, then T² is noncentral F with 1 and V
+	// degrees of freedom and noncentrality Delta².
+	td := NoncentralTDist{7, 1.5}
+	fd := NoncentralFDist{1, 7, 2.25}
+	for _, x := range []float64{0.1, 1, 2, 5, 20} {
+		want := td.CDF(math.Sqrt(x)) - td.CDF(-math.Sqrt(x))
+		if got := fd.CDF(x); !aeq(got, want) {
+			t.Errorf("%+v.CDF(%v) = %v, want %v", fd, x, got, want)
+		}
+		want = (td.PDF(math.Sqrt(x)) + td.PDF(-math.Sqrt(x))) / (2 * math.Sqrt(x))
+		if got := fd.PDF(x); !aeq(got, want) {
+			t.Errorf("%+v.PDF(%v) = %v, want %v", fd, x, got, want)
+		}
+	}
+
+	// Computed by numerical integration.
+	if got := (NoncentralFDist{3, 8, 2}).CDF(1.5); !aeqTol(got, 0.5012972130108305, 1e-7) {
+		t.Errorf("CDF(1.5) = %v, want 0.5012972130108305", got)
+	}
+
+	for _, d := range []NoncentralFDist{fd, {3, 8, 2}, {5, 20, 10}, {4, 3, 0}} {
+		testPDFIntegral(t, "NoncentralFDist", d, []float64{0.01, 0.5, 1, 2, 5, 10, 30})
+		testInvCDFRoundTrip(t, d)
+	}
+	d := NoncentralFDist{5, 20, 10}
+	if got := DistMean(struct{ Dist }{d}); !aeqTol(got, d.Mean(), 1e-5) {
+		t.Errorf("%+v: numerical mean %v, want %v", d, got, d.Mean())
+	}
+	if got := DistVariance(struct{ Dist }{d}); !aeqTol(got, d.Variance(), 1e-4) {
+		t.Errorf("%+v: numerical variance %v, want %v", d, got, d.Variance())
+	}
+}
@@ -0,0 +1,289 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package stats
+
+import (
+	"math"
+	"sort"
+)
+
+// An OutlierResult is the result of outlier detection on a Sample.
+type OutlierResult struct {
+	// Indexes are the indexes in the original Sample of the
+	// values identified as outliers, in increasing order.
+	Indexes []int
+
+	// Cleaned is a copy of the original Sample with the outliers
+	// removed. If the original Sample was weighted,
+	// Cleaned.Weights[i] is the original weight of
+	// Cleaned.Xs[i].
+	Cleaned Sample
+}
+
+// newOutlierResult returns the OutlierResult of removing the values
+// at indexes from s.
+func newOutlierResult(s Sample, indexes []int) *OutlierResult {
+	sort.Ints(indexes)
+	res := &OutlierResult{Indexes: indexes}
+	res.Cleaned.Sorted = s.Sorted
+	res.Cleaned.Xs = make([]float64, 0, len(s.Xs)-len(indexes))
+	if s.Weights != nil {
+		res.Cleaned.Weights = make([]float64, 0, len(s.Xs)-len(indexes))
+	}
+	for i, x := range s.Xs {
+		if len(indexes) > 0 && indexes[0] == i {
+			indexes = indexes[1:]
+			continue
+		}
+		res.Cleaned.Xs = append(res.Cleaned.Xs, x)
+		if s.Weights != nil {
+			res.Cleaned.Weights = append(res.Cleaned.Weights, s.Weights[i])
+		}
+	}
+	return res
+}
+
+// outside returns the indexes of all values in s that are < lo or >
+// hi.
+func (s Sample) outside(lo, hi float64) []int {
+	var indexes []int
+	for i, x := range s.Xs {
+		if x < lo || x > hi {
+			indexes = append(indexes, i)
+		}
+	}
+	return indexes
+}
+
+// TukeyOutliers identifies outliers in s using Tukey's fences. A value
+// is an outlier if it falls outside the range
+//
+//	[Q1 - k*IQR, Q3 + k*IQR]
+//
+// where Q1 and Q3 are the first and third quartiles of s and IQR is
+// the interquartile range. Conventionally, k is 1.5 for "outliers" and
+// 3 for "far out" values.
+//
+// Tukey, J. W. (1977). Exploratory Data Analysis. Addison-Wesley.
+func (s Sample) TukeyOutliers(k float64) *OutlierResult {
+	sorted := s
+	if !s.Sorted {
+		sorted = *s.Copy().Sort()
+	}
+	q1, q3 := sorted.Quantile(0.25), sorted.Quantile(0.75)
+	iqr := sorted.IQR()
+	return newOutlierResult(s, s.outside(q1-k*iqr, q3+k*iqr))
+}
+
+// ModifiedZScores returns the modified z-score of each value in s.
+// The modified z-score of x is
+//
+//	(x - median) / (MADNormal * MAD)
+//
+// where MAD is the median absolute deviation of s. This is analogous
+// to the standard z-score, but uses robust estimators of location and
+// scale. If the MAD is 0, this uses the mean absolute deviation from
+// the median, scaled by √(π/2), instead. If that is also 0, all
+// z-scores are 0.
+//
+// Iglewicz, B.; Hoaglin, D. C. (1993). How to Detect and Handle
+// Outliers. ASQC Quality Press.
+func (s Sample) ModifiedZScores() []float64 {
+	med := s.Quantile(0.5)
+	scale := s.MAD(MADNormal)
+	if scale == 0 {
+		devs := make([]float64, len(s.Xs))
+		for i, x := range s.Xs {
+			devs[i] = math.Abs(x - med)
+		}
+		scale = math.Sqrt(math.Pi/2) * Sample{Xs: devs, Weights: s.Weights}.Mean()
+	}
+	zs := make([]float64, len(s.Xs))
+	if scale == 0 {
+		return zs
+	}
+	for i, x := range s.Xs {
+		zs[i] = (x - med) / scale
+	}
+	return zs
+}
+
+// ModifiedZOutliers identifies outliers in s using the modified
+// z-score (see ModifiedZScores). A value is an outlier if the absolute
+// value of its modified z-score exceeds threshold. Iglewicz and
+// Hoaglin recommend a threshold of 3.5.
+func (s Sample) ModifiedZOutliers(threshold float64) *OutlierResult {
+	var indexes []int
+	for i, z := range s.ModifiedZScores() {
+		if math.Abs(z) > threshold {
+			indexes = append(indexes, i)
+		}
+	}
+	return newOutlierResult(s, indexes)
+}
+
+// A GrubbsTestResult is the result of Grubbs' test for outliers.
+type GrubbsTestResult struct {
+	// N is the size of the sample.
+	N int
+
+	// G is the value of Grubbs' test statistic. This is the
+	// largest absolute deviation from the sample mean, in units of
+	// the sample standard deviation.
+	G float64
+
+	// Index is the index in the Sample of the value farthest from
+	// the mean. This is the candidate outlier.
+	Index int
+
+	// P is the p-value of the test for the null hypothesis that
+	// there are no outliers in the sample.
+	P float64
+}
+
+// GrubbsTest performs Grubbs' test [1] of the null hypothesis that
+// there are no outliers in s against the alternative hypothesis that
+// there is exactly one outlier. This test assumes that s is otherwise
+// normally distributed.
+//
+// The test statistic G is the largest absolute deviation from the
+// sample mean in units of the sample standard deviation. The p-value
+// is computed from the t-distribution.
+//
+// This can fail with ErrSampleSize if s has fewer than 3 values or
+// ErrZeroVariance if all values of s are equal.
+//
+// [1] Grubbs, F. E. (1969). "Procedures for Detecting Outlying
+// Observations in Samples". Technometrics 11 (1): 1-21.
+func GrubbsTest(s Sample) (*GrubbsTestResult, error) {
+	n := s.Weight()
+	if n < 3 {
+		return nil, ErrSampleSize
+	}
+	mean, sd := s.Mean(), s.StdDev()
+	if sd == 0 {
+		return nil, ErrZeroVariance
+	}
+
+	g, index := 0.0, 0
+	for i, x := range s.Xs {
+		if s.Weights != nil && s.Weights[i] == 0 {
+			continue
+		}
+		if d := math.Abs(x-mean) / sd; d > g {
+			g, index = d, i
+		}
+	}
+
+	return &GrubbsTestResult{N: int(math.Round(n)), G: g, Index: index, P: grubbsP(g, n)}, nil
+}
+
+// grubbsP returns the p-value of Grubbs' statistic g in a sample of
+// size n.
+func grubbsP(g, n float64) float64 {
+	// G is a monotonic function of a t-distributed statistic with
+	// n-2 degrees of freedom. The probability that any one of the
+	// n values exceeds it gives a Bonferroni bound, which is exact
+	// when p is small enough that only one value can exceed it.
+	denom := (n-1)*(n-1) - n*g*g
+	if denom <= 0 {
+		// g is at its maximum possible value of (n-1)/√n.
+		return 0
+	}
+	t := math.Sqrt(n * (n - 2) * g * g / denom)
+	p := 2 * n * (1 - TDist{n - 2}.CDF(t))
+	return math.Min(1, p)
+}
+
+// GrubbsOutliers identifies at most one outlier in s using Grubbs'
+// test at significance level alpha. If the test rejects the null
+// hypothesis, the value farthest from the mean is an outlier.
+//
+// This fails under the same conditions as GrubbsTest.
+func (s Sample) GrubbsOutliers(alpha float64) (*OutlierResult, error) {
+	res, err := GrubbsTest(s)
+	if err != nil {
+		return nil, err
+	}
+	var indexes []int
+	if res.P < alpha {
+		indexes = []int{res.Index}
+	}
+	return newOutlierResult(s, indexes), nil
+}
+
+// ESDOutliers identifies up to maxOutliers outliers in s using
+// Rosner's generalized extreme Studentized deviate (ESD) test [1] at
+// significance level alpha. This test assumes that s is otherwise
+// normally distributed.
+//
+// Unlike repeatedly applying Grubbs' test, the generalized ESD test
+// is not susceptible to masking, where one outlier hides another, and
+// controls the overall probability of falsely identifying any outliers
+// at alpha.
+//
+// If s is weighted, removing a value removes its entire weight.
+//
+// This can fail with ErrSampleSize if maxOutliers is greater than the
+// size of s minus 2.
+//
+// [1] Rosner, B. (1983). "Percentage Points for a Generalized ESD
+// Many-Outlier Procedure". Technometrics 25 (2): 165-172.
+func (s Sample) ESDOutliers(maxOutliers int, alpha float64) (*OutlierResult, error) {
+	n := s.Weight()
+	if float64(maxOutliers) > n-2 {
+		return nil, ErrSampleSize
+	}
+
+	// Work on a copy of s from which we successively remove the
+	// most extreme value.
+	cur := Sample{Xs: append([]float64(nil), s.Xs...)}
+	orig := make([]int, len(s.Xs))
+	for i := range orig {
+		orig[i] = i
+	}
+	if s.Weights != nil {
+		cur.Weights = append([]float64(nil), s.Weights...)
+	}
+
+	var removed []int
+	nOutliers := 0
+	for i := 1; i <= maxOutliers; i++ {
+		nCur := cur.Weight()
+		mean, sd := cur.Mean(), cur.StdDev()
+		if nCur < 3 || sd == 0 {
+			break
+		}
+		r, index := 0.0, 0
+		for j, x := range cur.Xs {
+			if cur.Weights != nil && cur.Weights[j] == 0 {
+				continue
+			}
+			if d := math.Abs(x-mean) / sd; d > r {
+				r, index = d, j
+			}
+		}
+
+		// Compute the critical value λ_i.
+		p := 1 - alpha/(2*nCur)
+		t := InvCDF(TDist{nCur - 2})(p)
+		lambda := (nCur - 1) * t / math.Sqrt((nCur-2+t*t)*nCur)
+		if r > lambda {
+			nOutliers = i
+		}
+
+		// Remove the extreme value.
+		removed = append(removed, orig[index])
+		last := len(cur.Xs) - 1
+		cur.Xs[index], orig[index] = cur.Xs[last], orig[last]
+		cur.Xs, orig = cur.Xs[:last], orig[:last]
+		if cur.Weights != nil {
+			cur.Weights[index] = cur.Weights[last]
+			cur.Weights = cur.Weights[:last]
+		}
+	}
+
+	return newOutlierResult(s, removed[:nOutliers]), nil
+}
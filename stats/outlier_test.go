@@ -0,0 +1,114 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package stats
+
+import (
+	"reflect"
+	"testing"
+)
+
+func checkOutliers(t *testing.T, name string, s Sample, res *OutlierResult, want []int) {
+	t.Helper()
+	if len(res.Indexes) == 0 && len(want) == 0 {
+		res.Indexes = want
+	}
+	if !reflect.DeepEqual(want, res.Indexes) {
+		t.Errorf("%s: want outliers %v, got %v", name, want, res.Indexes)
+	}
+	if len(res.Cleaned.Xs)+len(res.Indexes) != len(s.Xs) {
+		t.Errorf("%s: cleaned sample has %d values, want %d", name, len(res.Cleaned.Xs), len(s.Xs)-len(res.Indexes))
+	}
+	if (s.Weights == nil) != (res.Cleaned.Weights == nil) ||
+		len(res.Cleaned.Weights) != 0 && len(res.Cleaned.Weights) != len(res.Cleaned.Xs) {
+		t.Errorf("%s: cleaned weights %v do not match values %v", name, res.Cleaned.Weights, res.Cleaned.Xs)
+	}
+	// Check that weights stay with their values.
+	j := 0
+	for i, x := range s.Xs {
+		if j < len(res.Indexes) && res.Indexes[j] == i {
+			j++
+			continue
+		}
+		k := i - j
+		if res.Cleaned.Xs[k] != x || s.Weights != nil && res.Cleaned.Weights[k] != s.Weights[i] {
+			t.Errorf("%s: cleaned sample %+v does not match original %+v", name, res.Cleaned, s)
+			break
+		}
+	}
+}
+
+var outlierSample = Sample{
+	Xs:      []float64{10.1, 9.8, 10.0, 25.0, 10.2, 9.9, 10.1, 10.3, 9.7, 10.0, 2.0, 10.05},
+	Weights: []float64{1, 2, 1, 1, 1, 1, 2, 1, 1, 1, 1, 1},
+}
+
+func TestTukeyOutliers(t *testing.T) {
+	s := outlierSample
+	checkOutliers(t, "Tukey", s, s.TukeyOutliers(1.5), []int{3, 10})
+	s.Weights = nil
+	checkOutliers(t, "Tukey", s, s.TukeyOutliers(1.5), []int{3, 10})
+	checkOutliers(t, "Tukey", s, s.TukeyOutliers(100), nil)
+}
+
+func TestModifiedZOutliers(t *testing.T) {
+	s := outlierSample
+	checkOutliers(t, "ModifiedZ", s, s.ModifiedZOutliers(3.5), []int{3, 10})
+	s.Weights = nil
+	checkOutliers(t, "ModifiedZ", s, s.ModifiedZOutliers(3.5), []int{3, 10})
+
+	// Zero MAD falls back to the mean absolute deviation.
+	s = Sample{Xs: []float64{1, 1, 1, 1, 1, 1, 100}}
+	checkOutliers(t, "ModifiedZ", s, s.ModifiedZOutliers(3.5), []int{6})
+	s = Sample{Xs: []float64{1, 1, 1}}
+	checkOutliers(t, "ModifiedZ", s, s.ModifiedZOutliers(3.5), nil)
+}
+
+func TestGrubbsTest(t *testing.T) {
+	s := Sample{Xs: []float64{199.31, 199.53, 200.19, 200.82, 201.92, 201.95, 202.18, 245.57}}
+	res, err := GrubbsTest(s)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if res.Index != 7 || !aeqTol(res.G, 2.4687, 1e-4) || res.P > 0.001 {
+		t.Errorf("want outlier 7 with G=2.4687, got %+v", res)
+	}
+
+	o, _ := s.GrubbsOutliers(0.05)
+	checkOutliers(t, "Grubbs", s, o, []int{7})
+	o, _ = o.Cleaned.GrubbsOutliers(0.05)
+	checkOutliers(t, "Grubbs", Sample{Xs: s.Xs[:7]}, o, nil)
+
+	if _, err := GrubbsTest(Sample{Xs: []float64{1, 1, 1}}); err != ErrZeroVariance {
+		t.Errorf("want ErrZeroVariance, got %v", err)
+	}
+}
+
+func TestESDOutliers(t *testing.T) {
+	s := outlierSample
+	s.Weights = nil
+
+	// Grubbs' test is fooled by masking: with two outliers on
+	// opposite sides, neither stands out.
+	xs := []float64{10.1, 9.8, 10.0, 25.0, 10.2, 9.9, 10.1, 25.1, 9.7, 10.0, 10.2, 10.05}
+	m := Sample{Xs: xs}
+	o, _ := m.GrubbsOutliers(0.05)
+	checkOutliers(t, "Grubbs", m, o, nil)
+	o, _ = m.ESDOutliers(4, 0.05)
+	checkOutliers(t, "ESD", m, o, []int{3, 7})
+
+	o, _ = s.ESDOutliers(4, 0.05)
+	checkOutliers(t, "ESD", s, o, []int{3, 10})
+	s.Weights = outlierSample.Weights
+	o, _ = s.ESDOutliers(4, 0.05)
+	checkOutliers(t, "ESD", s, o, []int{3, 10})
+
+	if _, err := s.ESDOutliers(13, 0.05); err != ErrSampleSize {
+		t.Errorf("want ErrSampleSize, got %v", err)
+	}
+}
+
+func aeqTol(a, b, tol float64) bool {
+	return a-b <= tol && b-a <= tol
+}
@@ -21,5 +21,16 @@ var nan = math.NaN()
 // TODO: Put all errors in the same place and maybe unify them.
 
 var (
-	ErrSamplesEqual = errors.New("all samples are equal")
+	ErrSamplesEqual  = errors.New("all samples are equal")
+	ErrSampleSupport = errors.New("sample is outside the support of the distribution")
+	ErrNoMoments     = errors.New("sample moments are not attainable by the distribution")
+
+	// ErrNotPositiveDefinite is returned when a covariance matrix
+	// is not symmetric positive definite, such as by
+	// NewMultivariateNormal.
+	ErrNotPositiveDefinite = errors.New("covariance matrix is not positive definite")
+
+	// ErrNoProgress is returned by GroupSequentialTest.Look if
+	// there is no new data since the previous look.
+	ErrNoProgress = errors.New("no new data since previous look")
 )
@@ -0,0 +1,283 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package stats
+
+import "math"
+
+// A TwoProportionTestResult is the result of a test comparing two
+// binomial proportions.
+type TwoProportionTestResult struct {
+	// Successes1 and Trials1 are the number of successes and
+	// trials in the first sample, and likewise for the second
+	// sample.
+	Successes1, Trials1 int
+	Successes2, Trials2 int
+
+	// Z is the value of the z statistic for this test.
+	Z float64
+
+	// AltHypothesis specifies the alternative hypothesis tested
+	// by this test against the null hypothesis that the two
+	// proportions are equal. LocationGreater means the first
+	// proportion is greater than the second.
+	AltHypothesis LocationHypothesis
+
+	// P is the p-value of this test for the given null
+	// hypothesis.
+	P float64
+}
+
+// TwoProportionZTest performs a two-proportion z-test of the null
+// hypothesis that the probability of success is the same in two
+// independent series of Bernoulli trials, given the number of
+// successes and trials in each series. This uses the pooled estimate
+// of the proportion to compute the standard error, so the two-sided
+// test is equivalent to a chi-squared test of the 2×2 contingency
+// table without Yates' continuity correction.
+//
+// This uses a normal approximation, so it is not accurate if the
+// expected number of successes or failures in either series is
+// small.
+//
+// This can fail with ErrSampleSize if either series has no trials or
+// ErrZeroVariance if all trials are successes or all trials are
+// failures.
+func TwoProportionZTest(successes1, trials1, successes2, trials2 int, alt LocationHypothesis) (*TwoProportionTestResult, error) {
+	checkProportion(successes1, trials1)
+	checkProportion(successes2, trials2)
+	if trials1 == 0 || trials2 == 0 {
+		return nil, ErrSampleSize
+	}
+	k1, n1 := float64(successes1), float64(trials1)
+	k2, n2 := float64(successes2), float64(trials2)
+	pool := (k1 + k2) / (n1 + n2)
+	se := math.Sqrt(pool * (1 - pool) * (1/n1 + 1/n2))
+	if se == 0 {
+		return nil, ErrZeroVariance
+	}
+	z := (k1/n1 - k2/n2) / se
+
+	var p float64
+	switch alt {
+	case LocationDiffers:
+		p = 2 * StdNormal.CDF(-math.Abs(z))
+	case LocationLess:
+		p = StdNormal.CDF(z)
+	case LocationGreater:
+		p = StdNormal.CDF(-z)
+	}
+	return &TwoProportionTestResult{
+		Successes1: successes1, Trials1: trials1,
+		Successes2: successes2, Trials2: trials2,
+		Z: z, AltHypothesis: alt, P: p,
+	}, nil
+}
+
+func checkProportion(successes, trials int) {
+	if successes < 0 || successes > trials {
+		panic("successes must be in [0, trials]")
+	}
+}
+
+// A ProportionDiffCIMethod is a method for computing the confidence
+// interval of the difference between two binomial proportions.
+type ProportionDiffCIMethod int
+
+//go:generate stringer -type=ProportionDiffCIMethod
+
+const (
+	// ProportionDiffNewcombe is Newcombe's hybrid score interval,
+	// which combines the Wilson score intervals of the two
+	// proportions. This is method 10 of Newcombe, R. G. (1998).
+	// "Interval estimation for the difference between
+	// independent proportions: comparison of eleven methods".
+	// Statistics in Medicine 17 (8): 873–890.
+	ProportionDiffNewcombe ProportionDiffCIMethod = iota
+
+	// ProportionDiffAgrestiCaffo is the Agresti-Caffo interval,
+	// which adds one success and one failure to each series and
+	// then uses the Wald interval. Agresti, A.; Caffo, B. (2000).
+	// "Simple and effective confidence intervals for proportions
+	// and differences of proportions result from adding two
+	// successes and two failures". The American Statistician
+	// 54 (4): 280–288.
+	ProportionDiffAgrestiCaffo
+)
+
+// ProportionDiffCI returns the confidence interval of p1 - p2 at the
+// given confidence level (e.g., 0.95), where p1 and p2 are the
+// probabilities of success in two independent series of Bernoulli
+// trials, computed using method. The result is always within
+// [-1, 1].
+//
+// If either series has no trials, this returns [-1, 1].
+func ProportionDiffCI(successes1, trials1, successes2, trials2 int, confidence float64, method ProportionDiffCIMethod) (lo, hi float64) {
+	checkProportion(successes1, trials1)
+	checkProportion(successes2, trials2)
+	if trials1 == 0 || trials2 == 0 {
+		return -1, 1
+	}
+	k1, n1 := float64(successes1), float64(trials1)
+	k2, n2 := float64(successes2), float64(trials2)
+
+	switch method {
+	case ProportionDiffNewcombe:
+		p1, p2 := k1/n1, k2/n2
+		l1, u1 := ProportionCI(successes1, trials1, confidence, ProportionWilson)
+		l2, u2 := ProportionCI(successes2, trials2, confidence, ProportionWilson)
+		d := p1 - p2
+		lo = d - math.Hypot(p1-l1, u2-p2)
+		hi = d + math.Hypot(u1-p1, p2-l2)
+
+	case ProportionDiffAgrestiCaffo:
+		z := -StdNormal.InvCDF((1 - confidence) / 2)
+		p1, p2 := (k1+1)/(n1+2), (k2+1)/(n2+2)
+		se := math.Sqrt(p1*(1-p1)/(n1+2) + p2*(1-p2)/(n2+2))
+		d := p1 - p2
+		lo, hi = d-z*se, d+z*se
+
+	default:
+		panic("unknown ProportionDiffCIMethod")
+	}
+	return math.Max(-1, lo), math.Min(1, hi)
+}
+
+// ProportionRatioCI returns the ratio p1 / p2 and its confidence
+// interval at the given confidence level (e.g., 0.95), where p1 and
+// p2 are the probabilities of success in two independent series of
+// Bernoulli trials. This is also known as the relative risk.
+//
+// This uses the log method of Katz et al. If either series has no
+// successes, it adds 0.5 to both numbers of successes and 1 to both
+// numbers of trials when computing the interval, so the interval is
+// always finite. The ratio itself is uncorrected, so it may be 0, +Inf,
+// or NaN. If either series has no trials, the interval is [0, +Inf].
+//
+// Katz, D.; Baptista, J.; Azen, S. P.; Pike, M. C. (1978).
+// "Obtaining confidence intervals for the risk ratio in cohort
+// studies". Biometrics 34 (3): 469–474.
+func ProportionRatioCI(successes1, trials1, successes2, trials2 int, confidence float64) (ratio, lo, hi float64) {
+	checkProportion(successes1, trials1)
+	checkProportion(successes2, trials2)
+	k1, n1 := float64(successes1), float64(trials1)
+	k2, n2 := float64(successes2), float64(trials2)
+	ratio = (k1 / n1) / (k2 / n2)
+	if trials1 == 0 || trials2 == 0 {
+		return ratio, 0, inf
+	}
+	if k1 == 0 || k2 == 0 {
+		k1, n1, k2, n2 = k1+0.5, n1+1, k2+0.5, n2+1
+	}
+	z := -StdNormal.InvCDF((1 - confidence) / 2)
+	logR := math.Log((k1 / n1) / (k2 / n2))
+	se := math.Sqrt(1/k1 - 1/n1 + 1/k2 - 1/n2)
+	return ratio, math.Exp(logR - z*se), math.Exp(logR + z*se)
+}
+
+// OddsRatioCI returns the odds ratio (p1/(1-p1)) / (p2/(1-p2)) and
+// its confidence interval at the given confidence level (e.g.,
+// 0.95), where p1 and p2 are the probabilities of success in two
+// independent series of Bernoulli trials.
+//
+// This uses Woolf's logit method. If any cell of the 2×2 table of
+// successes and failures is 0, it adds 0.5 to every cell when
+// computing the interval (the Haldane-Anscombe correction), so the
+// interval is always finite. The odds ratio itself is uncorrected,
+// so it may be 0, +Inf, or NaN. If either series has no trials, the
+// interval is [0, +Inf].
+//
+// Woolf, B. (1955). "On estimating the relation between blood group
+// and disease". Annals of Human Genetics 19 (4): 251–253.
+func OddsRatioCI(successes1, trials1, successes2, trials2 int, confidence float64) (ratio, lo, hi float64) {
+	checkProportion(successes1, trials1)
+	checkProportion(successes2, trials2)
+	a, b := float64(successes1), float64(trials1-successes1)
+	c, d := float64(successes2), float64(trials2-successes2)
+	ratio = (a * d) / (b * c)
+	if trials1 == 0 || trials2 == 0 {
+		return ratio, 0, inf
+	}
+	if a == 0 || b == 0 || c == 0 || d == 0 {
+		a, b, c, d = a+0.5, b+0.5, c+0.5, d+0.5
+	}
+	z := -StdNormal.InvCDF((1 - confidence) / 2)
+	logOR := math.Log((a * d) / (b * c))
+	se := math.Sqrt(1/a + 1/b + 1/c + 1/d)
+	return ratio, math.Exp(logOR - z*se), math.Exp(logOR + z*se)
+}
+
+// A RateRatioTestResult is the result of a test comparing two
+// Poisson rates.
+type RateRatioTestResult struct {
+	// Count1 and Exposure1 are the number of events and the
+	// exposure (e.g., time or number of runs) of the first
+	// series, and likewise for the second series.
+	Count1    int
+	Exposure1 float64
+	Count2    int
+	Exposure2 float64
+
+	// AltHypothesis specifies the alternative hypothesis tested
+	// by this test against the null hypothesis that the two
+	// rates are equal. LocationGreater means the rate of the
+	// first series is greater than the rate of the second.
+	AltHypothesis LocationHypothesis
+
+	// P is the p-value of this test for the given null
+	// hypothesis.
+	P float64
+}
+
+// RateRatio returns the observed ratio of the rate of the first
+// series to the rate of the second series.
+func (r *RateRatioTestResult) RateRatio() float64 {
+	return (float64(r.Count1) / r.Exposure1) / (float64(r.Count2) / r.Exposure2)
+}
+
+// CI returns the exact confidence interval of the ratio of the rate
+// of the first series to the rate of the second series at the given
+// confidence level (e.g., 0.95).
+//
+// This is derived from the Clopper-Pearson interval of the
+// proportion of events in the first series, so its coverage is at
+// least the requested confidence level.
+func (r *RateRatioTestResult) CI(confidence float64) (lo, hi float64) {
+	lo, hi = ProportionCI(r.Count1, r.Count1+r.Count2, confidence, ProportionClopperPearson)
+	// Map the proportion p to the rate ratio.
+	scale := r.Exposure2 / r.Exposure1
+	return lo / (1 - lo) * scale, hi / (1 - hi) * scale
+}
+
+// PoissonRateRatioTest performs an exact test of the null hypothesis
+// that two series of events occur at the same rate, given the number
+// of events in each series and the exposure over which they were
+// counted (e.g., time or number of runs). It assumes the counts are
+// independent and Poisson distributed.
+//
+// Under the null hypothesis, given the total number of events n, the
+// number of events in the first series is binomially distributed
+// with n trials and probability Exposure1 / (Exposure1 + Exposure2)
+// of success, so this is a conditional binomial test. This is the
+// same as the two-sample form of R's poisson.test.
+//
+// This can fail with ErrSampleSize if there are no events in either
+// series. It panics if either exposure is not positive.
+func PoissonRateRatioTest(count1 int, exposure1 float64, count2 int, exposure2 float64, alt LocationHypothesis) (*RateRatioTestResult, error) {
+	if count1 < 0 || count2 < 0 {
+		panic("counts must be non-negative")
+	}
+	if !(exposure1 > 0 && exposure2 > 0) {
+		panic("exposures must be positive")
+	}
+	b, err := BinomialTest(count1, count1+count2, exposure1/(exposure1+exposure2), alt)
+	if err != nil {
+		return nil, err
+	}
+	return &RateRatioTestResult{
+		Count1: count1, Exposure1: exposure1,
+		Count2: count2, Exposure2: exposure2,
+		AltHypothesis: alt, P: b.P,
+	}, nil
+}
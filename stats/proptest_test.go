@@ -0,0 +1,165 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package stats
+
+import (
+	"math"
+	"testing"
+)
+
+func TestTwoProportionZTest(t *testing.T) {
+	res, err := TwoProportionZTest(56, 70, 48, 80, LocationDiffers)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// The pooled proportion is 104/150.
+	pool := 104.0 / 150
+	z := 0.2 / math.Sqrt(pool*(1-pool)*(1.0/70+1.0/80))
+	if !aeq(res.Z, z) {
+		t.Errorf("Z = %v, want %v", res.Z, z)
+	}
+	// From R's prop.test(c(56, 48), c(70, 80), correct=FALSE).
+	if !aeqTol(res.P, 0.008043, 1e-3) {
+		t.Errorf("P = %v, want 0.008043", res.P)
+	}
+	res, _ = TwoProportionZTest(56, 70, 48, 80, LocationGreater)
+	if !aeq(res.P, StdNormal.CDF(-z)) {
+		t.Errorf("greater P = %v, want %v", res.P, StdNormal.CDF(-z))
+	}
+	res, _ = TwoProportionZTest(56, 70, 48, 80, LocationLess)
+	if !aeq(res.P, StdNormal.CDF(z)) {
+		t.Errorf("less P = %v, want %v", res.P, StdNormal.CDF(z))
+	}
+
+	if _, err := TwoProportionZTest(0, 0, 1, 2, LocationDiffers); err != ErrSampleSize {
+		t.Errorf("want ErrSampleSize, got %v", err)
+	}
+	if _, err := TwoProportionZTest(5, 5, 7, 7, LocationDiffers); err != ErrZeroVariance {
+		t.Errorf("want ErrZeroVariance, got %v", err)
+	}
+}
+
+func TestProportionDiffCI(t *testing.T) {
+	// Example (a) from Newcombe (1998), Table II.
+	lo, hi := ProportionDiffCI(56, 70, 48, 80, 0.95, ProportionDiffNewcombe)
+	if !aeqTol(lo, 0.0524, 1e-3) || !aeqTol(hi, 0.3339, 1e-3) {
+		t.Errorf("Newcombe CI = [%v, %v], want [0.0524, 0.3339]", lo, hi)
+	}
+	// Another example from Newcombe, where both proportions are 0.
+	lo, hi = ProportionDiffCI(0, 10, 0, 20, 0.95, ProportionDiffNewcombe)
+	if !aeqTol(lo, -0.1611, 1e-3) || !aeqTol(hi, 0.2775, 1e-3) {
+		t.Errorf("Newcombe CI = [%v, %v], want [-0.1611, 0.2775]", lo, hi)
+	}
+
+	lo, hi = ProportionDiffCI(56, 70, 48, 80, 0.95, ProportionDiffAgrestiCaffo)
+	p1, p2 := 57.0/72, 49.0/82
+	se := math.Sqrt(p1*(1-p1)/72 + p2*(1-p2)/82)
+	z := -StdNormal.InvCDF(0.025)
+	if !aeq(lo, p1-p2-z*se) || !aeq(hi, p1-p2+z*se) {
+		t.Errorf("Agresti-Caffo CI = [%v, %v], want [%v, %v]", lo, hi, p1-p2-z*se, p1-p2+z*se)
+	}
+
+	// Both methods should be within [-1, 1] and antisymmetric.
+	for _, method := range []ProportionDiffCIMethod{ProportionDiffNewcombe, ProportionDiffAgrestiCaffo} {
+		lo, hi := ProportionDiffCI(3, 3, 0, 4, 0.99, method)
+		if lo < -1 || hi > 1 || lo >= hi {
+			t.Errorf("%v: bad CI [%v, %v]", method, lo, hi)
+		}
+		lo2, hi2 := ProportionDiffCI(0, 4, 3, 3, 0.99, method)
+		if !aeq(lo, -hi2) || !aeq(hi, -lo2) {
+			t.Errorf("%v: CI [%v, %v] not antisymmetric with [%v, %v]", method, lo, hi, lo2, hi2)
+		}
+	}
+}
+
+func TestProportionRatioCI(t *testing.T) {
+	ratio, lo, hi := ProportionRatioCI(56, 70, 48, 80, 0.95)
+	se := math.Sqrt(1.0/56 - 1.0/70 + 1.0/48 - 1.0/80)
+	z := -StdNormal.InvCDF(0.025)
+	if want := 0.8 / 0.6; !aeq(ratio, want) {
+		t.Errorf("ratio = %v, want %v", ratio, want)
+	}
+	if want := ratio * math.Exp(-z*se); !aeq(lo, want) {
+		t.Errorf("lo = %v, want %v", lo, want)
+	}
+	if want := ratio * math.Exp(z*se); !aeq(hi, want) {
+		t.Errorf("hi = %v, want %v", hi, want)
+	}
+
+	// Zero counts give a finite interval.
+	ratio, lo, hi = ProportionRatioCI(0, 10, 4, 10, 0.95)
+	if ratio != 0 || !(lo > 0 && hi < inf && lo < hi) {
+		t.Errorf("got %v [%v, %v], want 0 with finite interval", ratio, lo, hi)
+	}
+
+	// With no trials, the interval is the whole parameter range.
+	for _, n := range [][2]int{{0, 10}, {10, 0}} {
+		if _, lo, hi := ProportionRatioCI(0, n[0], 0, n[1], 0.95); lo != 0 || hi != inf {
+			t.Errorf("with trials %v, got [%v, %v], want [0, +Inf]", n, lo, hi)
+		}
+	}
+}
+
+func TestOddsRatioCI(t *testing.T) {
+	ratio, lo, hi := OddsRatioCI(56, 70, 48, 80, 0.95)
+	if want := 56.0 * 32 / (14 * 48); !aeq(ratio, want) {
+		t.Errorf("ratio = %v, want %v", ratio, want)
+	}
+	if !aeqTol(lo, 1.2762, 1e-3) || !aeqTol(hi, 5.5723, 1e-3) {
+		t.Errorf("CI = [%v, %v], want [1.2762, 5.5723]", lo, hi)
+	}
+
+	ratio, lo, hi = OddsRatioCI(10, 10, 4, 10, 0.95)
+	if !math.IsInf(ratio, 1) || !(lo > 1 && hi < inf && lo < hi) {
+		t.Errorf("got %v [%v, %v], want +Inf with finite interval", ratio, lo, hi)
+	}
+
+	// With no trials, the interval is the whole parameter range.
+	for _, n := range [][2]int{{0, 10}, {10, 0}} {
+		if _, lo, hi := OddsRatioCI(0, n[0], 0, n[1], 0.95); lo != 0 || hi != inf {
+			t.Errorf("with trials %v, got [%v, %v], want [0, +Inf]", n, lo, hi)
+		}
+	}
+}
+
+func TestPoissonRateRatioTest(t *testing.T) {
+	res, err := PoissonRateRatioTest(10, 2, 4, 3, LocationDiffers)
+	if err != nil {
+		t.Fatal(err)
+	}
+	b, _ := BinomialTest(10, 14, 0.4, LocationDiffers)
+	if !aeq(res.P, b.P) {
+		t.Errorf("P = %v, want %v", res.P, b.P)
+	}
+	if want := (10.0 / 2) / (4.0 / 3); !aeq(res.RateRatio(), want) {
+		t.Errorf("RateRatio = %v, want %v", res.RateRatio(), want)
+	}
+
+	// Swapping the series inverts the interval.
+	lo, hi := res.CI(0.95)
+	if r := res.RateRatio(); !(lo < r && r < hi) {
+		t.Errorf("CI [%v, %v] does not contain %v", lo, hi, r)
+	}
+	res2, _ := PoissonRateRatioTest(4, 3, 10, 2, LocationDiffers)
+	lo2, hi2 := res2.CI(0.95)
+	if !aeq(lo, 1/hi2) || !aeq(hi, 1/lo2) {
+		t.Errorf("CI [%v, %v] not reciprocal of [%v, %v]", lo, hi, lo2, hi2)
+	}
+	if !aeq(res.P, res2.P) {
+		t.Errorf("swapped P = %v, want %v", res2.P, res.P)
+	}
+
+	// The interval bounds are the rate ratios at which the
+	// one-sided tests have p-value α/2.
+	hiP := BinomialDist{N: 14, P: hi * 2 / (hi*2 + 3)}.CDF(10)
+	loP := 1 - BinomialDist{N: 14, P: lo * 2 / (lo*2 + 3)}.CDF(9)
+	if !aeqTol(hiP, 0.025, 1e-6) || !aeqTol(loP, 0.025, 1e-6) {
+		t.Errorf("tail probabilities at CI bounds = %v, %v, want 0.025", loP, hiP)
+	}
+
+	if _, err := PoissonRateRatioTest(0, 1, 0, 1, LocationDiffers); err != ErrSampleSize {
+		t.Errorf("want ErrSampleSize, got %v", err)
+	}
+}
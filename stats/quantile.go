@@ -0,0 +1,248 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package stats
+
+import (
+	"math"
+
+	"github.com/aclements/go-moremath/mathx"
+)
+
+// QuantileMethod represents a definition of the sample quantile.
+//
+// Methods QuantileR1 through QuantileR9 are the nine definitions
+// described by Hyndman and Fan [1], numbered as in their paper. These
+// are also the nine types supported by R's quantile function. R uses
+// QuantileR7 by default, as does NumPy's percentile function.
+// Hyndman and Fan recommend QuantileR8, since it is approximately
+// median-unbiased regardless of the distribution.
+//
+// All of these methods treat sample weights as frequency weights. For
+// integer weights, the result is exactly the result for the
+// unweighted sample in which each value is repeated according to its
+// weight.
+//
+// [1] Hyndman, R. J.; Fan, Y. (1996). "Sample Quantiles in
+// Statistical Packages". The American Statistician 50 (4): 361-365.
+type QuantileMethod int
+
+//go:generate stringer -type=QuantileMethod
+
+const (
+	// QuantileDefault is the method used by Sample.Quantile. For
+	// an unweighted sample, this is QuantileR8. For a weighted
+	// sample, this is the smallest value x such that more than q
+	// of the total weight is <= x.
+	QuantileDefault QuantileMethod = iota
+
+	// QuantileR1 is the inverse of the empirical CDF.
+	QuantileR1
+
+	// QuantileR2 is like QuantileR1, but averages at
+	// discontinuities.
+	QuantileR2
+
+	// QuantileR3 is the nearest order statistic, choosing the
+	// even one on ties. This is the definition used by SAS.
+	QuantileR3
+
+	// QuantileR4 is linear interpolation of the empirical CDF.
+	QuantileR4
+
+	// QuantileR5 is a piecewise linear function where the knots
+	// are at the midpoints of the steps of the empirical CDF.
+	QuantileR5
+
+	// QuantileR6 is linear interpolation of the expectations of
+	// the order statistics of a uniform distribution. This is the
+	// definition used by Minitab and SPSS.
+	QuantileR6
+
+	// QuantileR7 is linear interpolation of the modes of the
+	// order statistics of a uniform distribution. This is the
+	// default in R, NumPy, and Excel.
+	QuantileR7
+
+	// QuantileR8 is linear interpolation of the approximate
+	// medians of the order statistics. The result is
+	// approximately median-unbiased regardless of the
+	// distribution.
+	QuantileR8
+
+	// QuantileR9 is approximately unbiased for the expected order
+	// statistics if the sample is normally distributed.
+	QuantileR9
+
+	// QuantileHarrellDavis is the Harrell-Davis estimator [1],
+	// which is a weighted sum of all of the order statistics with
+	// weights given by a beta distribution. This is generally more
+	// efficient than the other methods for small samples, but is
+	// not robust to outliers and takes linear time.
+	//
+	// [1] Harrell, F. E.; Davis, C. E. (1982). "A New
+	// Distribution-Free Quantile Estimator". Biometrika 69 (3):
+	// 635-640.
+	QuantileHarrellDavis
+)
+
+// QuantileWith is like Quantile, but computes the q'th quantile using
+// the given method.
+//
+// q will be capped to the range [0, 1]. If len(xs) == 0 or all
+// weights are 0, returns NaN.
+//
+// This is constant time if s.Sorted and s.Weights == nil, except for
+// QuantileHarrellDavis, which always takes linear time.
+func (s Sample) QuantileWith(q float64, method QuantileMethod) float64 {
+	if method == QuantileDefault {
+		return s.Quantile(q)
+	}
+	if len(s.Xs) == 0 || s.Weight() == 0 {
+		return math.NaN()
+	} else if q <= 0 {
+		min, _ := s.Bounds()
+		return min
+	} else if q >= 1 {
+		_, max := s.Bounds()
+		return max
+	}
+
+	if !s.Sorted {
+		s = *s.Copy().Sort()
+	}
+	o := newOrderStats(s)
+	n := o.n
+
+	switch method {
+	case QuantileR1:
+		return o.at(math.Ceil(n * q))
+
+	case QuantileR2:
+		j := n * q
+		if j == math.Floor(j) {
+			return (o.at(j) + o.at(j+1)) / 2
+		}
+		return o.at(math.Ceil(j))
+
+	case QuantileR3:
+		j := math.Floor(n*q - 0.5)
+		if n*q-0.5 == j && math.Mod(j, 2) == 0 {
+			return o.at(j)
+		}
+		return o.at(j + 1)
+
+	case QuantileHarrellDavis:
+		return o.harrellDavis(q)
+	}
+
+	// Continuous methods linearly interpolate between order
+	// statistics at 1-based position h.
+	var h float64
+	switch method {
+	case QuantileR4:
+		h = n * q
+	case QuantileR5:
+		h = n*q + 1/2.0
+	case QuantileR6:
+		h = (n + 1) * q
+	case QuantileR7:
+		h = (n-1)*q + 1
+	case QuantileR8:
+		h = (n+1/3.0)*q + 1/3.0
+	case QuantileR9:
+		h = (n+1/4.0)*q + 3/8.0
+	default:
+		panic("unknown QuantileMethod")
+	}
+	j := math.Floor(h)
+	lo, hi := o.at(j), o.at(j+1)
+	return lo + (h-j)*(hi-lo)
+}
+
+// IQRWith is like IQR, but computes the quartiles using the given
+// method.
+func (s Sample) IQRWith(method QuantileMethod) float64 {
+	if !s.Sorted {
+		s = *s.Copy().Sort()
+	}
+	return s.QuantileWith(0.75, method) - s.QuantileWith(0.25, method)
+}
+
+// orderStats provides access to the order statistics of a sorted,
+// possibly weighted Sample, treating the weights as frequency
+// weights.
+type orderStats struct {
+	s Sample
+
+	// n is the total weight of s.
+	n float64
+
+	// cum[i] is the total weight of s.Xs[:i+1]. This is nil if s
+	// is unweighted.
+	cum []float64
+}
+
+func newOrderStats(s Sample) *orderStats {
+	o := &orderStats{s: s}
+	if s.Weights == nil {
+		o.n = float64(len(s.Xs))
+		return o
+	}
+	o.cum = make([]float64, len(s.Weights))
+	for i, w := range s.Weights {
+		o.n += w
+		o.cum[i] = o.n
+	}
+	return o
+}
+
+// at returns the k'th order statistic, where k is 1-based. If k < 1
+// or k > n, this returns the minimum or maximum value, respectively.
+// For a weighted sample, this is the value x_i such that
+// cum[i-1] < k <= cum[i].
+func (o *orderStats) at(k float64) float64 {
+	xs := o.s.Xs
+	if o.cum == nil {
+		if k < 1 {
+			return xs[0]
+		} else if k >= o.n {
+			return xs[len(xs)-1]
+		}
+		return xs[int(k)-1]
+	}
+
+	if k > o.n {
+		k = o.n
+	}
+	for i, c := range o.cum {
+		if c >= k && o.s.Weights[i] > 0 {
+			return xs[i]
+		}
+	}
+	return xs[len(xs)-1]
+}
+
+// harrellDavis returns the Harrell-Davis estimate of the q'th
+// quantile.
+func (o *orderStats) harrellDavis(q float64) float64 {
+	a, b := (o.n+1)*q, (o.n+1)*(1-q)
+	sum, prevI, prevCum := 0.0, 0.0, 0.0
+	for i, x := range o.s.Xs {
+		cum := float64(i + 1)
+		if o.cum != nil {
+			cum = o.cum[i]
+		}
+		if cum == prevCum {
+			continue
+		}
+		I := 1.0
+		if cum < o.n {
+			I = mathx.BetaInc(cum/o.n, a, b)
+		}
+		sum += (I - prevI) * x
+		prevI, prevCum = I, cum
+	}
+	return sum
+}
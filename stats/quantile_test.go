@@ -0,0 +1,109 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package stats
+
+import (
+	"math"
+	"testing"
+)
+
+func TestQuantileWith(t *testing.T) {
+	// Expected values were computed with a port of R's
+	// quantile.default.
+	s := Sample{Xs: []float64{35, 15, 50, 20, 40}}
+	qs := []float64{0.05, 0.1, 0.3, 0.4, 0.5, 0.7, 0.9, 0.95}
+	want := map[QuantileMethod][]float64{
+		QuantileR1: {15, 15, 20, 20, 35, 40, 50, 50},
+		QuantileR2: {15, 15, 20, 27.5, 35, 40, 50, 50},
+		QuantileR3: {15, 15, 20, 20, 20, 40, 40, 50},
+		QuantileR4: {15, 15, 17.5, 20, 27.5, 37.5, 45, 47.5},
+		QuantileR5: {15, 15, 20, 27.5, 35, 40, 50, 50},
+		QuantileR6: {15, 15, 19, 26, 35, 42, 50, 50},
+		QuantileR7: {16, 17, 23, 29, 35, 39, 46, 48},
+		QuantileR8: {15, 15, 19.666666666666668, 27, 35, 40.666666666666664, 50, 50},
+		QuantileR9: {15, 15, 19.75, 27.125, 35, 40.5, 50, 50},
+	}
+	for method, ws := range want {
+		for i, q := range qs {
+			if got := s.QuantileWith(q, method); !aeq(got, ws[i]) {
+				t.Errorf("%v: QuantileWith(%v) = %v, want %v", method, q, got, ws[i])
+			}
+		}
+		if got := s.QuantileWith(0, method); got != 15 {
+			t.Errorf("%v: QuantileWith(0) = %v, want 15", method, got)
+		}
+		if got := s.QuantileWith(1, method); got != 50 {
+			t.Errorf("%v: QuantileWith(1) = %v, want 50", method, got)
+		}
+	}
+
+	// QuantileDefault is Quantile.
+	for _, q := range qs {
+		if got, want := s.QuantileWith(q, QuantileDefault), s.Quantile(q); got != want {
+			t.Errorf("QuantileDefault: QuantileWith(%v) = %v, want %v", q, got, want)
+		}
+	}
+
+	if got := (Sample{}).QuantileWith(0.5, QuantileR7); !math.IsNaN(got) {
+		t.Errorf("QuantileWith of empty sample = %v, want NaN", got)
+	}
+}
+
+func TestQuantileWithWeighted(t *testing.T) {
+	// With integer weights, a weighted sample must give the same
+	// results as the sample with each value repeated.
+	s := Sample{Xs: []float64{3, 1, 9, 2, 7}, Weights: []float64{3, 2, 0, 1, 1}}
+	rep := Sample{Xs: []float64{1, 1, 2, 3, 3, 3, 7}}
+	for method := QuantileR1; method <= QuantileHarrellDavis; method++ {
+		for _, q := range []float64{0.01, 0.1, 0.125, 0.25, 0.5, 0.8, 0.99} {
+			got, want := s.QuantileWith(q, method), rep.QuantileWith(q, method)
+			if !aeq(got, want) {
+				t.Errorf("%v: weighted QuantileWith(%v) = %v, want %v", method, q, got, want)
+			}
+		}
+	}
+
+	if got := (Sample{Xs: []float64{1}, Weights: []float64{0}}).QuantileWith(0.5, QuantileR7); !math.IsNaN(got) {
+		t.Errorf("QuantileWith with zero weight = %v, want NaN", got)
+	}
+}
+
+func TestQuantileHarrellDavis(t *testing.T) {
+	// For these q, the beta parameters are integers, so the
+	// expected values can be computed exactly from binomial sums.
+	s := Sample{Xs: []float64{15, 20, 35, 40, 50}}
+	testFunc(t, "HarrellDavis", func(q float64) float64 {
+		return s.QuantileWith(q, QuantileHarrellDavis)
+	}, map[float64]float64{
+		1 / 6.0: 17.8592,
+		2 / 6.0: 24.2432,
+		3 / 6.0: 32.1152,
+		5 / 6.0: 46.1792,
+	})
+}
+
+func TestIQRWith(t *testing.T) {
+	s := Sample{Xs: []float64{15, 20, 35, 40, 50}}
+	if got := s.IQRWith(QuantileR7); got != 20 {
+		t.Errorf("IQRWith(QuantileR7) = %v, want 20", got)
+	}
+	if got, want := s.IQRWith(QuantileDefault), s.IQR(); got != want {
+		t.Errorf("IQRWith(QuantileDefault) = %v, want %v", got, want)
+	}
+}
+
+func TestQuantileCIMethod(t *testing.T) {
+	s := Sample{Xs: []float64{15, 20, 35, 40, 50}}
+	ci := QuantileCI(len(s.Xs), 0.3, 0.5)
+	q1, lo1, hi1 := ci.SampleCI(s)
+	ci.Method = QuantileR7
+	q2, lo2, hi2 := ci.SampleCI(s)
+	if q1 != s.Quantile(0.3) || !aeq(q2, 23) {
+		t.Errorf("want quantiles %v and 23, got %v and %v", s.Quantile(0.3), q1, q2)
+	}
+	if lo1 != lo2 || hi1 != hi2 {
+		t.Errorf("Method changed the CI from [%v, %v] to [%v, %v]", lo1, hi1, lo2, hi2)
+	}
+}
@@ -38,11 +38,18 @@ type QuantileCIResult struct {
 	// ambiguous. In this case, the interval LoOrder+1 to
 	// HiOrder+1 has equivalent confidence.
 	Ambiguous bool
+
+	// Method is the method SampleCI uses to compute the sample
+	// quantile. QuantileCI sets this to QuantileDefault. This
+	// does not affect the confidence interval, which is always
+	// bounded by order statistics.
+	Method QuantileMethod
 }
 
 // SampleCI returns the quantile and its confidence interval for a
 // sample given the parameters in ci. It may return negative or
-// positive infinity if the interval lies outside the sample.
+// positive infinity if the interval lies outside the sample. The
+// quantile is computed using ci.Method.
 func (ci QuantileCIResult) SampleCI(s Sample) (q, lo, hi float64) {
 	if s.Weights != nil {
 		panic("Cannot compute quantile CI on a weighted sample")
@@ -55,7 +62,7 @@ func (ci QuantileCIResult) SampleCI(s Sample) (q, lo, hi float64) {
 		s = *s.Copy().Sort()
 	}
 
-	q = s.Quantile(ci.Quantile)
+	q = s.QuantileWith(ci.Quantile, ci.Method)
 	if ci.LoOrder < 1 {
 		// The sample is too small or the confidence is too high.
 		lo = math.Inf(-1)
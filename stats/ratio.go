@@ -0,0 +1,72 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package stats
+
+import "math"
+
+// FiellerCI returns the ratio of the means of two independent samples,
+// mean(x1)/mean(x2), and its confidence interval computed using
+// Fieller's theorem [1].
+//
+// Unlike the naive approach of dividing the confidence intervals of
+// the two means, Fieller's interval accounts for the uncertainty in
+// both the numerator and the denominator and is correctly asymmetric
+// around the ratio. For example, if x1 and x2 are benchmark results
+// from a new and an old version of a program, FiellerCI reports
+// new/old with a confidence interval.
+//
+// This assumes the sample means are normally distributed. It does not
+// assume the samples have equal variance; the critical value is taken
+// from the t-distribution with degrees of freedom given by the
+// Welch-Satterthwaite approximation.
+//
+// If the mean of x2 is not significantly different from 0 at the
+// given confidence level, the confidence set is unbounded and this
+// returns -Inf, +Inf. This also returns an unbounded interval if
+// either sample has fewer than 2 values or the mean of x2 is 0.
+//
+// [1] Fieller, E. C. (1954). "Some Problems in Interval Estimation".
+// Journal of the Royal Statistical Society B 16 (2): 175-185.
+func FiellerCI(x1, x2 TTestSample, confidence float64) (ratio, lo, hi float64) {
+	n1, n2 := x1.Weight(), x2.Weight()
+	m1, m2 := x1.Mean(), x2.Mean()
+	ratio = m1 / m2
+
+	if confidence <= 0 {
+		return ratio, ratio, ratio
+	} else if confidence >= 1 || n1 <= 1 || n2 <= 1 || m2 == 0 {
+		return ratio, math.Inf(-1), math.Inf(1)
+	}
+
+	// Variances of the sample means.
+	v1, v2 := x1.Variance()/n1, x2.Variance()/n2
+
+	// The ratio ρ is in the confidence set if
+	//
+	//   (m1 - ρ m2)² <= t² (v1 + ρ² v2).
+	//
+	// Solving for the roots of this quadratic in ρ gives
+	//
+	//   ρ = (r ± (t/|m2|) √(v1(1-g) + r² v2)) / (1-g)
+	//
+	// where r = m1/m2 and g = t² v2 / m2².
+	dof := math.Pow(v1+ratio*ratio*v2, 2) /
+		(v1*v1/(n1-1) + math.Pow(ratio*ratio*v2, 2)/(n2-1))
+	if math.IsNaN(dof) {
+		// Both variances are 0.
+		return ratio, ratio, ratio
+	}
+	t := -InvCDF(TDist{dof})((1 - confidence) / 2)
+	g := t * t * v2 / (m2 * m2)
+	if g >= 1 {
+		// The denominator is not significantly different
+		// from 0.
+		return ratio, math.Inf(-1), math.Inf(1)
+	}
+	w := t / math.Abs(m2) * math.Sqrt(v1*(1-g)+ratio*ratio*v2)
+	lo = (ratio - w) / (1 - g)
+	hi = (ratio + w) / (1 - g)
+	return ratio, lo, hi
+}
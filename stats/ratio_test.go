@@ -0,0 +1,48 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package stats
+
+import (
+	"math"
+	"testing"
+)
+
+func TestFiellerCI(t *testing.T) {
+	x1 := Sample{Xs: []float64{97, 96, 98, 99, 95}}
+	x2 := Sample{Xs: []float64{100, 101, 99, 100, 100}}
+
+	// If the denominator has no variance, this is just a scaled
+	// CI of the numerator.
+	c := Sample{Xs: []float64{100, 100, 100}}
+	r, lo, hi := FiellerCI(x1, c, 0.95)
+	m, mlo, mhi := x1.MeanCI(0.95)
+	if !aeq(m/100, r) || !aeq(mlo/100, lo) || !aeq(mhi/100, hi) {
+		t.Errorf("want %v [%v, %v], got %v [%v, %v]", m/100, mlo/100, mhi/100, r, lo, hi)
+	}
+
+	// The bounds should be the roots of Fieller's quadratic.
+	r, lo, hi = FiellerCI(x1, x2, 0.95)
+	if !aeq(0.97, r) || !(lo < r && r < hi) {
+		t.Errorf("want 0.97 within [%v, %v]", lo, hi)
+	}
+	m1, m2 := x1.Mean(), x2.Mean()
+	v1, v2 := x1.Variance()/5, x2.Variance()/5
+	for _, rho := range []float64{lo, hi} {
+		// Recover t² from the root.
+		t2 := math.Pow(m1-rho*m2, 2) / (v1 + rho*rho*v2)
+		dof := math.Pow(v1+r*r*v2, 2) / (v1*v1/4 + math.Pow(r*r*v2, 2)/4)
+		tc := InvCDF(TDist{dof})(0.975)
+		if !aeq(tc*tc, t2) {
+			t.Errorf("bound %v is not a root: t²=%v, want %v", rho, t2, tc*tc)
+		}
+	}
+
+	// A denominator indistinguishable from 0 gives an unbounded
+	// interval.
+	z := Sample{Xs: []float64{-1, 1, -2, 2.5}}
+	if _, lo, hi := FiellerCI(x1, z, 0.95); !math.IsInf(lo, -1) || !math.IsInf(hi, 1) {
+		t.Errorf("want unbounded interval, got [%v, %v]", lo, hi)
+	}
+}
@@ -0,0 +1,392 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package stats
+
+import "math"
+
+// trim returns the sorted values and weights of s after trimming
+// alpha of the sample from each end, along with the lowest and highest
+// remaining values. For an unweighted sample of size n, this trims
+// ⌊alpha*n⌋ values from each end. For a weighted sample with total
+// weight W, this trims exactly alpha*W weight from each end, reducing
+// the weight of the values at the boundary if necessary.
+//
+// alpha must be in [0, 0.5).
+func (s Sample) trim(alpha float64) (xs, ws []float64, lo, hi float64) {
+	if alpha < 0 || alpha >= 0.5 {
+		panic("trimming proportion must be in [0, 0.5)")
+	}
+	if !s.Sorted {
+		s = *s.Copy().Sort()
+	}
+
+	var g float64
+	if s.Weights == nil {
+		g = math.Floor(alpha * float64(len(s.Xs)))
+	} else {
+		g = alpha * s.Weight()
+	}
+
+	xs = s.Xs
+	ws = make([]float64, len(xs))
+	if s.Weights == nil {
+		for i := range ws {
+			ws[i] = 1
+		}
+	} else {
+		copy(ws, s.Weights)
+	}
+
+	// Remove g weight from each end.
+	cut := func(order func(int) int) (boundary float64) {
+		rem := g
+		for i := range ws {
+			j := order(i)
+			if ws[j] == 0 {
+				continue
+			}
+			if rem < ws[j] {
+				ws[j] -= rem
+				return xs[j]
+			}
+			rem -= ws[j]
+			ws[j] = 0
+		}
+		panic("trimmed entire sample")
+	}
+	lo = cut(func(i int) int { return i })
+	hi = cut(func(i int) int { return len(ws) - i - 1 })
+	return
+}
+
+// TrimmedMean returns the α-trimmed mean of the Sample. This is the
+// mean of the sample after discarding the lowest and highest alpha
+// fraction of values. For an unweighted sample of size n, this
+// discards ⌊alpha*n⌋ values from each end. For a weighted sample with
+// total weight W, this discards exactly alpha*W weight from each end.
+//
+// TrimmedMean(0) is the arithmetic mean, and as alpha approaches 0.5,
+// the trimmed mean approaches the median. alpha must be in [0, 0.5).
+//
+// If the Sample is empty or all weights are 0, this returns NaN.
+func (s Sample) TrimmedMean(alpha float64) float64 {
+	if s.Weight() == 0 {
+		return nan
+	}
+	xs, ws, _, _ := s.trim(alpha)
+	return Sample{Xs: xs, Weights: ws}.Mean()
+}
+
+// winsorize returns the values and weights of the α-winsorized
+// sample, along with the fraction of the total weight that was
+// trimmed from each end to construct it.
+func (s Sample) winsorize(alpha float64) (w Sample, gamma float64) {
+	xs, ws, lo, hi := s.trim(alpha)
+	total, kept := s.Weight(), 0.0
+	for _, w := range ws {
+		kept += w
+	}
+	g := (total - kept) / 2
+	// Add the trimmed weight back at the boundary values.
+	wxs := make([]float64, 0, len(xs)+2)
+	wxs = append(append(append(wxs, lo), xs...), hi)
+	wws := make([]float64, 0, len(ws)+2)
+	wws = append(append(append(wws, g), ws...), g)
+	return Sample{Xs: wxs, Weights: wws, Sorted: true}, g / total
+}
+
+// WinsorizedMean returns the α-winsorized mean of the Sample. This is
+// the mean of the sample after replacing the lowest and highest alpha
+// fraction of values with the lowest and highest remaining values.
+// See TrimmedMean for how values are selected.
+//
+// alpha must be in [0, 0.5). If the Sample is empty or all weights are
+// 0, this returns NaN.
+func (s Sample) WinsorizedMean(alpha float64) float64 {
+	if s.Weight() == 0 {
+		return nan
+	}
+	w, _ := s.winsorize(alpha)
+	return w.Mean()
+}
+
+// WinsorizedVariance returns the α-winsorized sample variance of the
+// Sample. This is the sample variance of the α-winsorized Sample.
+//
+// alpha must be in [0, 0.5). If the Sample is empty or all weights are
+// 0, this returns NaN.
+func (s Sample) WinsorizedVariance(alpha float64) float64 {
+	if s.Weight() == 0 {
+		return nan
+	}
+	w, _ := s.winsorize(alpha)
+	return w.Variance()
+}
+
+// robustMeanSE returns the standard error of the α-trimmed or
+// α-winsorized mean using the winsorized variance.
+func (s Sample) robustMeanSE(alpha float64) float64 {
+	n := s.Weight()
+	if n == 0 {
+		return nan
+	}
+	w, gamma := s.winsorize(alpha)
+	return math.Sqrt(w.Variance()) / ((1 - 2*gamma) * math.Sqrt(n))
+}
+
+// TrimmedMeanSE returns the estimated standard error of the α-trimmed
+// mean of the Sample. This is the Tukey-McLaughlin estimator
+//
+//	s_w / ((1-2γ) √n)
+//
+// where s_w is the α-winsorized standard deviation and γ is the
+// fraction of the sample trimmed from each end.
+//
+// Tukey, J. W.; McLaughlin, D. H. (1963). "Less Vulnerable Confidence
+// and Significance Procedures for Location Based on a Single Sample:
+// Trimming/Winsorization 1". Sankhyā A 25 (3): 331-352.
+func (s Sample) TrimmedMeanSE(alpha float64) float64 {
+	return s.robustMeanSE(alpha)
+}
+
+// WinsorizedMeanSE returns the estimated standard error of the
+// α-winsorized mean of the Sample. This uses the same estimator as
+// TrimmedMeanSE, as recommended by Wilcox.
+//
+// Wilcox, R. R. (2012). Introduction to Robust Estimation and
+// Hypothesis Testing, 3rd edition. Academic Press.
+func (s Sample) WinsorizedMeanSE(alpha float64) float64 {
+	return s.robustMeanSE(alpha)
+}
+
+// MADNormal is the consistency constant for the median absolute
+// deviation at the normal distribution. This is 1/Φ⁻¹(3/4), where Φ⁻¹
+// is the inverse CDF of the standard normal distribution. Passing this
+// to Sample.MAD gives a consistent estimator of the standard deviation
+// of normally distributed data.
+const MADNormal = 1.482602218505602
+
+// MAD returns the median absolute deviation of the Sample, scaled by
+// consistency constant c. This is
+//
+//	c * median(|Xs[i] - median(Xs)|)
+//
+// Typically, c is either 1 or MADNormal.
+//
+// If the Sample is weighted, this uses weighted medians.
+//
+// If the Sample is empty or all weights are 0, this returns NaN.
+func (s Sample) MAD(c float64) float64 {
+	if s.Weight() == 0 {
+		return nan
+	}
+	med := s.Quantile(0.5)
+	devs := make([]float64, len(s.Xs))
+	for i, x := range s.Xs {
+		devs[i] = math.Abs(x - med)
+	}
+	return c * Sample{Xs: devs, Weights: s.Weights}.Quantile(0.5)
+}
+
+// Sn returns the Sn estimator of scale of the Sample, which is
+//
+//	c * lomed_i himed_j |Xs[i] - Xs[j]|
+//
+// where lomed is the low median, himed is the high median, and c is a
+// consistency constant that makes this an unbiased estimator of the
+// standard deviation of normally distributed data. Sn has a 50%
+// breakdown point, like the MAD, but is more efficient and does not
+// assume a symmetric distribution.
+//
+// This takes O(n²) time. The Sample must not be weighted.
+//
+// Rousseeuw, P. J.; Croux, C. (1993). "Alternatives to the Median
+// Absolute Deviation". Journal of the American Statistical
+// Association 88 (424): 1273-1283.
+func (s Sample) Sn() float64 {
+	if s.Weights != nil {
+		panic("Weighted Sn not implemented")
+	}
+	n := len(s.Xs)
+	if n == 0 {
+		return nan
+	} else if n == 1 {
+		return 0
+	}
+
+	// TODO: Croux and Rousseeuw (1992) give an O(n log n)
+	// algorithm.
+	inner := make([]float64, n)
+	outer := make([]float64, n)
+	for i, xi := range s.Xs {
+		for j, xj := range s.Xs {
+			inner[j] = math.Abs(xi - xj)
+		}
+		outer[i] = selectK(inner, n/2) // High median.
+	}
+	sn := selectK(outer, (n+1)/2-1) // Low median.
+
+	// Finite sample correction factors.
+	cn := 1.0
+	if n <= 9 {
+		cn = [...]float64{2: 0.743, 1.851, 0.954, 1.351, 0.993, 1.198, 1.005, 1.131}[n]
+	} else if n%2 == 1 {
+		cn = float64(n) / (float64(n) - 0.9)
+	}
+	return 1.1926 * cn * sn
+}
+
+// Qn returns the Qn estimator of scale of the Sample, which is
+//
+//	d * {|Xs[i] - Xs[j]|; i < j}_(k)
+//
+// where _(k) denotes the k'th order statistic, k = (h choose 2), h =
+// ⌊n/2⌋+1, and d is a consistency constant that makes this an
+// unbiased estimator of the standard deviation of normally distributed
+// data. Qn has a 50% breakdown point and 82% efficiency at the normal
+// distribution.
+//
+// This takes O(n²) time. The Sample must not be weighted.
+//
+// Rousseeuw, P. J.; Croux, C. (1993). "Alternatives to the Median
+// Absolute Deviation". Journal of the American Statistical
+// Association 88 (424): 1273-1283.
+func (s Sample) Qn() float64 {
+	if s.Weights != nil {
+		panic("Weighted Qn not implemented")
+	}
+	n := len(s.Xs)
+	if n == 0 {
+		return nan
+	} else if n == 1 {
+		return 0
+	}
+
+	// TODO: Croux and Rousseeuw (1992) give an O(n log n)
+	// algorithm.
+	diffs := make([]float64, 0, n*(n-1)/2)
+	for i, xi := range s.Xs {
+		for _, xj := range s.Xs[i+1:] {
+			diffs = append(diffs, math.Abs(xi-xj))
+		}
+	}
+	h := n/2 + 1
+	k := h * (h - 1) / 2
+	qn := selectK(diffs, k-1)
+
+	// Finite sample correction factors.
+	var dn float64
+	if n <= 9 {
+		dn = [...]float64{2: 0.399, 0.994, 0.512, 0.844, 0.611, 0.857, 0.669, 0.872}[n]
+	} else if n%2 == 1 {
+		dn = float64(n) / (float64(n) + 1.4)
+	} else {
+		dn = float64(n) / (float64(n) + 3.8)
+	}
+	return 2.2219 * dn * qn
+}
+
+// selectK returns the k'th smallest value in xs (where k is
+// 0-based). It reorders xs.
+func selectK(xs []float64, k int) float64 {
+	// This is Hoare's quickselect with median-of-three pivots.
+	lo, hi := 0, len(xs)-1
+	for lo < hi {
+		mid := lo + (hi-lo)/2
+		if xs[mid] < xs[lo] {
+			xs[mid], xs[lo] = xs[lo], xs[mid]
+		}
+		if xs[hi] < xs[lo] {
+			xs[hi], xs[lo] = xs[lo], xs[hi]
+		}
+		if xs[hi] < xs[mid] {
+			xs[hi], xs[mid] = xs[mid], xs[hi]
+		}
+		pivot := xs[mid]
+		i, j := lo, hi
+		for i <= j {
+			for xs[i] < pivot {
+				i++
+			}
+			for pivot < xs[j] {
+				j--
+			}
+			if i <= j {
+				xs[i], xs[j] = xs[j], xs[i]
+				i++
+				j--
+			}
+		}
+		if k <= j {
+			hi = j
+		} else if k >= i {
+			lo = i
+		} else {
+			return xs[k]
+		}
+	}
+	return xs[k]
+}
+
+// HuberK is the conventional tuning constant for Huber's M-estimator
+// of location. It gives 95% efficiency at the normal distribution.
+const HuberK = 1.345
+
+// Huber returns Huber's M-estimate of the location of the Sample with
+// tuning constant k (typically HuberK). This is the value μ that
+// minimizes
+//
+//	∑ ρ((Xs[i] - μ) / σ)
+//
+// where ρ(z) = z²/2 for |z| <= k and k|z| - k²/2 otherwise, and σ is
+// the normalized MAD of the Sample. Values within k·σ of the
+// location contribute as they would to the mean, while values farther
+// away contribute as they would to the median. Hence, as k approaches
+// infinity, this approaches the mean, and as k approaches 0, it
+// approaches the median.
+//
+// This is computed by iteratively reweighted least squares, starting
+// from the median. If the Sample is weighted, each term of the sum is
+// weighted.
+//
+// If the MAD of the Sample is 0, this returns the median. If the
+// Sample is empty or all weights are 0, this returns NaN.
+//
+// Huber, P. J. (1964). "Robust Estimation of a Location Parameter".
+// Annals of Mathematical Statistics 35 (1): 73-101.
+func (s Sample) Huber(k float64) float64 {
+	if s.Weight() == 0 {
+		return nan
+	}
+	mu := s.Quantile(0.5)
+	sigma := s.MAD(MADNormal)
+	if sigma == 0 {
+		return mu
+	}
+
+	const maxIterations = 100
+	const tolerance = 1e-10
+	for iter := 0; iter < maxIterations; iter++ {
+		// Each value gets IRLS weight ψ(z)/z, where ψ = ρ'.
+		num, den := 0.0, 0.0
+		for i, x := range s.Xs {
+			w := 1.0
+			if s.Weights != nil {
+				w = s.Weights[i]
+			}
+			if z := math.Abs(x-mu) / sigma; z > k {
+				w *= k / z
+			}
+			num += w * x
+			den += w
+		}
+		next := num / den
+		if math.Abs(next-mu) <= tolerance*sigma {
+			return next
+		}
+		mu = next
+	}
+	return mu
+}
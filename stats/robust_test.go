@@ -0,0 +1,140 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package stats
+
+import (
+	"math"
+	"sort"
+	"testing"
+)
+
+func TestTrimmedMean(t *testing.T) {
+	s := Sample{Xs: []float64{100, 1, 2, 3, 4, 5, 6, 7, 8, 9}}
+	ones := make([]float64, len(s.Xs))
+	for i := range ones {
+		ones[i] = 1
+	}
+	sw := Sample{Xs: s.Xs, Weights: ones}
+
+	for _, s := range []Sample{s, sw} {
+		if got := s.TrimmedMean(0); !aeq(s.Mean(), got) {
+			t.Errorf("want TrimmedMean(0)=%v, got %v", s.Mean(), got)
+		}
+		if got := s.TrimmedMean(0.1); !aeq(5.5, got) {
+			t.Errorf("want TrimmedMean(0.1)=5.5, got %v", got)
+		}
+		if got := s.WinsorizedMean(0.1); !aeq(5.5, got) {
+			t.Errorf("want WinsorizedMean(0.1)=5.5, got %v", got)
+		}
+		wvar := 66.5 / 9
+		if got := s.WinsorizedVariance(0.1); !aeq(wvar, got) {
+			t.Errorf("want WinsorizedVariance(0.1)=%v, got %v", wvar, got)
+		}
+		se := math.Sqrt(wvar) / (0.8 * math.Sqrt(10))
+		if got := s.TrimmedMeanSE(0.1); !aeq(se, got) {
+			t.Errorf("want TrimmedMeanSE(0.1)=%v, got %v", se, got)
+		}
+		if got := s.WinsorizedMeanSE(0.1); !aeq(se, got) {
+			t.Errorf("want WinsorizedMeanSE(0.1)=%v, got %v", se, got)
+		}
+	}
+
+	// An unweighted sample trims whole values, while a weighted
+	// sample trims exact weight.
+	if got := s.TrimmedMean(0.15); !aeq(5.5, got) {
+		t.Errorf("want TrimmedMean(0.15)=5.5, got %v", got)
+	}
+	if got := sw.TrimmedMean(0.15); !aeq((0.5*2+3+4+5+6+7+8+0.5*9)/7, got) {
+		t.Errorf("want weighted TrimmedMean(0.15)=%v, got %v", (0.5*2+3+4+5+6+7+8+0.5*9)/7, got)
+	}
+}
+
+func TestMAD(t *testing.T) {
+	s := Sample{Xs: []float64{1, 2, 3, 4, 100}}
+	if got := s.MAD(1); got != 1 {
+		t.Errorf("want MAD(1)=1, got %v", got)
+	}
+	if got := s.MAD(MADNormal); !aeq(MADNormal, got) {
+		t.Errorf("want MAD(MADNormal)=%v, got %v", MADNormal, got)
+	}
+	if want := 1 / StdNormal.InvCDF(0.75); !aeq(want, MADNormal) {
+		t.Errorf("MADNormal=%v, want %v", MADNormal, want)
+	}
+}
+
+func TestSnQn(t *testing.T) {
+	s := Sample{Xs: []float64{1, 2, 3, 4}}
+	if got, want := s.Sn(), 1.1926*0.954; !aeq(want, got) {
+		t.Errorf("want Sn=%v, got %v", want, got)
+	}
+	if got, want := s.Qn(), 2.2219*0.512; !aeq(want, got) {
+		t.Errorf("want Qn=%v, got %v", want, got)
+	}
+
+	// Both should be close to the standard deviation of a large
+	// normal sample, and robust to outliers.
+	var xs []float64
+	for _, p := range openLinspace(1000) {
+		xs = append(xs, NormalDist{0, 2}.InvCDF(p))
+	}
+	xs[0], xs[1] = 1e6, -1e6
+	s = Sample{Xs: xs}
+	if got := s.Sn(); math.Abs(got-2) > 0.05 {
+		t.Errorf("want Sn≈2, got %v", got)
+	}
+	if got := s.Qn(); math.Abs(got-2) > 0.05 {
+		t.Errorf("want Qn≈2, got %v", got)
+	}
+}
+
+// openLinspace returns n evenly spaced points in (0, 1).
+func openLinspace(n int) []float64 {
+	out := make([]float64, n)
+	for i := range out {
+		out[i] = (float64(i) + 0.5) / float64(n)
+	}
+	return out
+}
+
+func TestSelectK(t *testing.T) {
+	xs := []float64{5, 3, 9, 1, 1, 7, 3, 8, 2}
+	sorted := append([]float64(nil), xs...)
+	sort.Float64s(sorted)
+	for k := range xs {
+		if got := selectK(append([]float64(nil), xs...), k); got != sorted[k] {
+			t.Errorf("selectK(%v, %d): want %v, got %v", xs, k, sorted[k], got)
+		}
+	}
+}
+
+func TestHuber(t *testing.T) {
+	s := Sample{Xs: []float64{1, 2, 3, 4, 100}}
+
+	// With a huge k, this is the mean.
+	if got := s.Huber(1e9); !aeq(s.Mean(), got) {
+		t.Errorf("want Huber(inf)=%v, got %v", s.Mean(), got)
+	}
+
+	// Check that the estimate solves the estimating equation.
+	mu, sigma := s.Huber(HuberK), s.MAD(MADNormal)
+	sum := 0.0
+	for _, x := range s.Xs {
+		z := (x - mu) / sigma
+		sum += math.Max(-HuberK, math.Min(HuberK, z))
+	}
+	if math.Abs(sum) > 1e-8 {
+		t.Errorf("Huber(%v)=%v does not solve ∑ψ=0 (got %v)", HuberK, mu, sum)
+	}
+	if mu < 2 || mu > 4 {
+		t.Errorf("Huber(%v)=%v not robust to outlier", HuberK, mu)
+	}
+
+	// Weights are frequency weights.
+	sw := Sample{Xs: []float64{1, 2, 100}, Weights: []float64{2, 2, 1}}
+	su := Sample{Xs: []float64{1, 1, 2, 2, 100}}
+	if want, got := su.Huber(HuberK), sw.Huber(HuberK); !aeq(want, got) {
+		t.Errorf("want weighted Huber=%v, got %v", want, got)
+	}
+}
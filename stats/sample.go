@@ -135,9 +135,15 @@ func (s Sample) Mean() float64 {
 		//   m_i = (1 - w_i/wsum_i) * m_(i-1) + (w_i/wsum_i) * x_i
 		//       = m_(i-1) + (x_i - m_(i-1)) * (w_i/wsum_i)
 		w := s.Weights[i]
+		if w == 0 {
+			continue
+		}
 		wsum += w
 		m += (x - m) * w / wsum
 	}
+	if wsum == 0 {
+		return math.NaN()
+	}
 	return m
 }
 
@@ -145,35 +151,42 @@ func (s Sample) Mean() float64 {
 // interval based on the sample standard deviation.
 func MeanCI(xs []float64, confidence float64) (mean, lo, hi float64) {
 	mean = Mean(xs)
+	w := meanCIWidth(float64(len(xs)), confidence, func() float64 { return StdDev(xs) })
+	return mean, mean - w, mean + w
+}
 
-	var w float64
+// meanCIWidth returns the half-width of the confidence interval of
+// the mean of a sample of size n with sample standard deviation
+// stdDev().
+func meanCIWidth(n, confidence float64, stdDev func() float64) float64 {
 	if confidence <= 0 {
 		// At confidence level 0, the CI width is 0.
-		w = 0
-	} else if confidence >= 1 || len(xs) <= 1 {
+		return 0
+	} else if confidence >= 1 || n <= 1 {
 		// With confidence level 1, we don't know anything.
 		// This is also the case if the sample is too small to
 		// have a CI.
-		w = math.Inf(1)
-	} else {
-		s := StdDev(xs)
-		tdist := TDist{V: float64(len(xs) - 1)}
-		alpha := (1 - confidence) / 2
-		t := -InvCDF(tdist)(alpha)
-		w = t * s / math.Sqrt(float64(len(xs)))
+		return math.Inf(1)
 	}
-
-	return mean, mean - w, mean + w
+	s := stdDev()
+	tdist := TDist{V: n - 1}
+	alpha := (1 - confidence) / 2
+	t := -InvCDF(tdist)(alpha)
+	return t * s / math.Sqrt(n)
 }
 
 // MeanCI returns the arithmetic mean of the Sample and its confidence
 // interval based on the sample standard deviation.
+//
+// If the Sample is weighted, the weights are treated as frequency
+// weights.
 func (s Sample) MeanCI(confidence float64) (mean, lo, hi float64) {
 	if len(s.Xs) == 0 || s.Weights == nil {
 		return MeanCI(s.Xs, confidence)
 	}
-	// TODO(austin)
-	panic("Weighted MeanCI not implemented")
+	mean = s.Mean()
+	w := meanCIWidth(s.Weight(), confidence, s.StdDev)
+	return mean, mean - w, mean + w
 }
 
 // GeoMean returns the geometric mean of xs. xs must be positive.
@@ -202,13 +215,81 @@ func (s Sample) GeoMean() float64 {
 	m, wsum := 0.0, 0.0
 	for i, x := range s.Xs {
 		w := s.Weights[i]
+		if w == 0 {
+			continue
+		}
 		wsum += w
 		lx := math.Log(x)
 		m += (lx - m) * w / wsum
 	}
+	if wsum == 0 {
+		return math.NaN()
+	}
 	return math.Exp(m)
 }
 
+// GeoMeanCI returns the geometric mean of xs and its confidence
+// interval. xs must be positive.
+//
+// The interval is computed in log space: it is the exponential of
+// the confidence interval of the arithmetic mean of log(xs) (see
+// MeanCI). Hence, it is asymmetric around the geometric mean. This
+// is appropriate for summarizing ratios, such as benchmark speedups,
+// where the geometric mean is the natural measure of central
+// tendency.
+func GeoMeanCI(xs []float64, confidence float64) (mean, lo, hi float64) {
+	return Sample{Xs: xs}.GeoMeanCI(confidence)
+}
+
+// GeoMeanCI returns the geometric mean of the Sample and its
+// confidence interval. All samples values must be positive.
+//
+// See GeoMeanCI for details.
+func (s Sample) GeoMeanCI(confidence float64) (mean, lo, hi float64) {
+	logs := Sample{Xs: make([]float64, len(s.Xs)), Weights: s.Weights}
+	for i, x := range s.Xs {
+		if x <= 0 {
+			return math.NaN(), math.NaN(), math.NaN()
+		}
+		logs.Xs[i] = math.Log(x)
+	}
+	mean, lo, hi = logs.MeanCI(confidence)
+	return math.Exp(mean), math.Exp(lo), math.Exp(hi)
+}
+
+// HarmonicMean returns the harmonic mean of xs. xs must be positive.
+func HarmonicMean(xs []float64) float64 {
+	return Sample{Xs: xs}.HarmonicMean()
+}
+
+// HarmonicMean returns the harmonic mean of the Sample. All samples
+// values must be positive.
+//
+// The harmonic mean is the appropriate average of rates. For
+// example, if each sample is the throughput of one run of a fixed
+// amount of work, the harmonic mean is the total throughput.
+func (s Sample) HarmonicMean() float64 {
+	if len(s.Xs) == 0 {
+		return math.NaN()
+	}
+	invSum, wsum := 0.0, 0.0
+	for i, x := range s.Xs {
+		if x <= 0 {
+			return math.NaN()
+		}
+		w := 1.0
+		if s.Weights != nil {
+			w = s.Weights[i]
+		}
+		invSum += w / x
+		wsum += w
+	}
+	if wsum == 0 {
+		return math.NaN()
+	}
+	return wsum / invSum
+}
+
 // Variance returns the sample variance of xs.
 func Variance(xs []float64) float64 {
 	if len(xs) == 0 {
@@ -230,12 +311,35 @@ func Variance(xs []float64) float64 {
 	return M2 / float64(len(xs)-1)
 }
 
+// Variance returns the sample variance of the Sample.
+//
+// If the Sample is weighted, the weights are treated as frequency
+// weights, so the result is the variance of a sample in which each
+// Xs[i] appears Weights[i] times.
 func (s Sample) Variance() float64 {
 	if len(s.Xs) == 0 || s.Weights == nil {
 		return Variance(s.Xs)
 	}
-	// TODO(austin)
-	panic("Weighted Variance not implemented")
+
+	// This is the weighted generalization of Welford's algorithm
+	// from West, D. H. D. (1979). "Updating Mean and Variance
+	// Estimates: An Improved Method". Communications of the ACM
+	// 22 (9): 532-535.
+	mean, M2, wsum := 0.0, 0.0, 0.0
+	for i, x := range s.Xs {
+		w := s.Weights[i]
+		if w == 0 {
+			continue
+		}
+		wsum += w
+		delta := x - mean
+		mean += delta * w / wsum
+		M2 += w * delta * (x - mean)
+	}
+	if wsum <= 1 {
+		return 0
+	}
+	return M2 / (wsum - 1)
 }
 
 // StdDev returns the sample standard deviation of xs.
@@ -248,8 +352,7 @@ func (s Sample) StdDev() float64 {
 	if len(s.Xs) == 0 || s.Weights == nil {
 		return StdDev(s.Xs)
 	}
-	// TODO(austin)
-	panic("Weighted StdDev not implemented")
+	return math.Sqrt(s.Variance())
 }
 
 // Quantile returns the sample value X at which q*weight of the sample
@@ -263,7 +366,8 @@ func (s Sample) StdDev() float64 {
 // the first and third quartiles, respectively. Quantile(P/100) is the
 // P'th percentile.
 //
-// See also function QuantileCI.
+// See also function QuantileCI and method QuantileWith, which supports
+// other definitions of the sample quantile.
 //
 // This is constant time if s.Sorted and s.Weights == nil.
 func (s Sample) Quantile(q float64) float64 {
@@ -311,7 +415,8 @@ func (s Sample) Quantile(q float64) float64 {
 	}
 }
 
-// IQR returns the interquartile range of the Sample.
+// IQR returns the interquartile range of the Sample. See also
+// IQRWith.
 //
 // This is constant time if s.Sorted and s.Weights == nil.
 func (s Sample) IQR() float64 {
@@ -52,3 +52,48 @@ func TestMeanCI(t *testing.T) {
 	check(0.95, math.NaN(), math.NaN(), math.NaN())
 	check(1, math.NaN(), math.NaN(), math.NaN())
 }
+
+func TestSampleWeightedVariance(t *testing.T) {
+	s := Sample{Xs: []float64{1, 2, 3, 5}, Weights: []float64{1, 2, 0, 3}}
+	want := Variance([]float64{1, 2, 2, 5, 5, 5})
+	if got := s.Variance(); !aeq(want, got) {
+		t.Errorf("want variance %v, got %v", want, got)
+	}
+	if got := s.StdDev(); !aeq(math.Sqrt(want), got) {
+		t.Errorf("want stddev %v, got %v", math.Sqrt(want), got)
+	}
+}
+
+func TestSampleWeightedMeanCI(t *testing.T) {
+	s := Sample{Xs: []float64{1, 2, 3, 5}, Weights: []float64{1, 2, 0, 3}}
+	wm, wlo, whi := MeanCI([]float64{1, 2, 2, 5, 5, 5}, 0.95)
+	m, lo, hi := s.MeanCI(0.95)
+	if !aeq(wm, m) || !aeq(wlo, lo) || !aeq(whi, hi) {
+		t.Errorf("want %v@[%v,%v], got %v@[%v,%v]", wm, wlo, whi, m, lo, hi)
+	}
+}
+
+func TestGeoMeanCI(t *testing.T) {
+	xs := []float64{1, 2, 4, 8}
+	m, lo, hi := GeoMeanCI(xs, 0.95)
+	lm, llo, lhi := MeanCI([]float64{0, math.Ln2, 2 * math.Ln2, 3 * math.Ln2}, 0.95)
+	if !aeq(math.Pow(2, 1.5), m) || !aeq(math.Exp(lm), m) || !aeq(math.Exp(llo), lo) || !aeq(math.Exp(lhi), hi) {
+		t.Errorf("want %v@[%v,%v], got %v@[%v,%v]", math.Exp(lm), math.Exp(llo), math.Exp(lhi), m, lo, hi)
+	}
+	if m, _, _ := GeoMeanCI([]float64{1, -1}, 0.95); !math.IsNaN(m) {
+		t.Errorf("want NaN for non-positive sample, got %v", m)
+	}
+}
+
+func TestHarmonicMean(t *testing.T) {
+	if got, want := HarmonicMean([]float64{1, 2, 4}), 3/1.75; !aeq(want, got) {
+		t.Errorf("want %v, got %v", want, got)
+	}
+	s := Sample{Xs: []float64{1, 2, 4}, Weights: []float64{2, 0, 1}}
+	if got, want := s.HarmonicMean(), 3/2.25; !aeq(want, got) {
+		t.Errorf("want %v, got %v", want, got)
+	}
+	if got := HarmonicMean([]float64{1, 0}); !math.IsNaN(got) {
+		t.Errorf("want NaN, got %v", got)
+	}
+}
@@ -0,0 +1,316 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package stats
+
+import "math"
+
+// This file implements sequential tests, which may be repeated as
+// data arrives and stopped as soon as they reject the null
+// hypothesis without inflating the false positive rate. By contrast,
+// repeating a fixed-sample test such as TwoSampleWelchTTest until it
+// rejects makes a false positive all but certain.
+//
+// Both tests compare the means of two samples, and accept any
+// TTestSample, such as a *StreamStats, holding all of the data seen
+// so far. Both use the sample variances as if they were the known
+// population variances, so they are approximate for small samples.
+
+// MSPRT is a mixture sequential probability ratio test of the null
+// hypothesis that two samples are drawn from populations with equal
+// means, against the alternative that the means differ. This test
+// produces "always-valid" p-values: at any stopping time, even one
+// that depends on the data seen so far, Pr[P <= α] <= α under the
+// null hypothesis.
+//
+// The test statistic is the likelihood ratio of the observed
+// difference in means under a normal mixture of alternative
+// hypotheses with mean 0 and standard deviation Tau, against the null
+// hypothesis. Tau should be on the order of the smallest difference
+// in means that is of practical interest; the test is most powerful
+// for differences near Tau, but is valid for any Tau > 0.
+//
+// Johari, R.; Koomen, P.; Pekelis, L.; Walsh, D. (2017). "Peeking at
+// A/B Tests: Why it matters, and what to do about it". Proceedings of
+// the 23rd ACM SIGKDD International Conference on Knowledge Discovery
+// and Data Mining: 1517–1525.
+type MSPRT struct {
+	// Tau is the standard deviation of the mixing distribution
+	// over the difference in means.
+	Tau float64
+
+	// P is the always-valid p-value after the most recent
+	// update. This never increases.
+	P float64
+
+	// Diff and Var are the estimated difference in means,
+	// x1 - x2, and its variance at the most recent update.
+	Diff, Var float64
+}
+
+// NewMSPRT returns a new mixture sequential probability ratio test
+// with mixing standard deviation tau, which must be positive.
+func NewMSPRT(tau float64) *MSPRT {
+	if !(tau > 0) {
+		panic("MSPRT requires tau > 0")
+	}
+	return &MSPRT{Tau: tau, P: 1, Var: inf}
+}
+
+// Update updates the test given all of the data from each sample so
+// far and returns the always-valid p-value.
+//
+// This can fail with ErrSampleSize if either sample has fewer than
+// two values or ErrZeroVariance if both samples have zero variance.
+// In this case, the test is unchanged.
+func (t *MSPRT) Update(x1, x2 TTestSample) (float64, error) {
+	n1, n2 := x1.Weight(), x2.Weight()
+	if n1 <= 1 || n2 <= 1 {
+		return t.P, ErrSampleSize
+	}
+	v := x1.Variance()/n1 + x2.Variance()/n2
+	if v == 0 {
+		return t.P, ErrZeroVariance
+	}
+	t.Diff, t.Var = x1.Mean()-x2.Mean(), v
+
+	// The log of the mixture likelihood ratio.
+	tau2 := t.Tau * t.Tau
+	logLR := 0.5*math.Log(v/(v+tau2)) + t.Diff*t.Diff*tau2/(2*v*(v+tau2))
+	t.P = math.Min(t.P, math.Exp(-logLR))
+	return t.P, nil
+}
+
+// CI returns an always-valid confidence interval of the difference in
+// means, x1 - x2, at the given confidence level (e.g., 0.95) as of
+// the most recent update. The sequence of intervals from every update
+// contains the true difference at all times with probability at
+// least confidence. If there has been no successful update, this
+// returns (-Inf, +Inf).
+func (t *MSPRT) CI(confidence float64) (lo, hi float64) {
+	if math.IsInf(t.Var, 1) {
+		return -inf, inf
+	}
+	// This is the set of differences d for which the likelihood
+	// ratio of the observed difference, centered at d, is less
+	// than 1/α.
+	v, tau2 := t.Var, t.Tau*t.Tau
+	half := math.Sqrt(v * (v + tau2) / tau2 * (2*math.Log(1/(1-confidence)) + math.Log((v+tau2)/v)))
+	return t.Diff - half, t.Diff + half
+}
+
+// An AlphaSpending is an alpha spending function for a group
+// sequential test. It determines how much of the total false positive
+// rate is "spent" at each look, as a function of the fraction of the
+// planned information (sample size) observed so far.
+type AlphaSpending int
+
+//go:generate stringer -type=AlphaSpending
+
+const (
+	// SpendingOBrienFleming is the Lan-DeMets spending function
+	// that approximates O'Brien-Fleming boundaries, applied to
+	// α/2 in each tail, α(t) = 4 - 4Φ(z_{α/4} / √t). This spends
+	// very little at early looks, so the final look is almost as
+	// powerful as a fixed-sample test.
+	SpendingOBrienFleming AlphaSpending = iota
+
+	// SpendingPocock is the Lan-DeMets spending function that
+	// approximates Pocock boundaries, α(t) = α log(1 + (e-1)t).
+	// This spends more evenly across looks, so it is more likely
+	// to stop early, but less powerful at the final look.
+	SpendingPocock
+)
+
+// spent returns the cumulative false positive rate spent at
+// information fraction t.
+func (s AlphaSpending) spent(alpha, t float64) float64 {
+	if t >= 1 {
+		return alpha
+	}
+	switch s {
+	case SpendingOBrienFleming:
+		return 4 * StdNormal.CDF(StdNormal.InvCDF(alpha/4)/math.Sqrt(t))
+	case SpendingPocock:
+		return alpha * math.Log(1+(math.E-1)*t)
+	}
+	panic("unknown AlphaSpending")
+}
+
+// GroupSequentialTest is a two-sided group sequential test of the null
+// hypothesis that two samples are drawn from populations with equal
+// means, with boundaries determined by an alpha spending function.
+// Unlike a fixed-sample test, this may be repeated after each new
+// batch of data (each "look"), and stopped at the first look that
+// rejects the null hypothesis, while keeping the overall false
+// positive rate at Alpha.
+//
+// The test must be planned for a maximum total sample size. The
+// looks need not be planned in advance, but the decision of when to
+// look must not depend on the data seen so far.
+//
+// Lan, K. K. Gordon; DeMets, David L. (1983). "Discrete sequential
+// boundaries for clinical trials". Biometrika 70 (3): 659–663.
+type GroupSequentialTest struct {
+	// Alpha is the overall false positive rate.
+	Alpha float64
+
+	// Spending is the alpha spending function.
+	Spending AlphaSpending
+
+	// MaxN is the planned maximum total size of both samples.
+	MaxN float64
+
+	// look is the number of looks so far, t is the information
+	// fraction at the previous look, and spent is the false
+	// positive rate spent so far.
+	look     int
+	t, spent float64
+
+	// density is the sub-density of the standardized sum
+	// S = Z√t at the previous look over the continuation region,
+	// evaluated at the points of grid.
+	grid, density []float64
+}
+
+// GroupSequentialResult is the result of a look at the data in a
+// GroupSequentialTest.
+type GroupSequentialResult struct {
+	// Look is the number of this look, starting at 1.
+	Look int
+
+	// Fraction is the fraction of the planned information
+	// observed at this look.
+	Fraction float64
+
+	// Z is the z statistic of the difference in means, x1 - x2.
+	Z float64
+
+	// Bound is the critical value of |Z| for this look. This is
+	// +Inf if the spending function does not allow rejection at
+	// this look.
+	Bound float64
+
+	// Spent is the cumulative false positive rate spent as of
+	// this look.
+	Spent float64
+
+	// Reject indicates that |Z| >= Bound, so the test rejects the
+	// null hypothesis and should be stopped.
+	Reject bool
+}
+
+// NewGroupSequentialTest returns a new group sequential test with an
+// overall false positive rate of alpha, using the given spending
+// function, and a planned maximum total sample size of maxN.
+func NewGroupSequentialTest(alpha float64, spending AlphaSpending, maxN float64) *GroupSequentialTest {
+	if !(0 < alpha && alpha < 1) {
+		panic("alpha must be in (0, 1)")
+	}
+	if !(maxN > 0) {
+		panic("maxN must be positive")
+	}
+	return &GroupSequentialTest{Alpha: alpha, Spending: spending, MaxN: maxN}
+}
+
+// groupSeqGridSize is the number of points used to represent the
+// density of the test statistic between looks.
+const groupSeqGridSize = 401
+
+// Look performs an interim analysis of the test given all of the data
+// from each sample so far. The information fraction of this look is
+// the total size of the samples divided by MaxN. If this is 1 or more,
+// this is the final look, and the test spends the remaining false
+// positive rate.
+//
+// This can fail with ErrSampleSize if either sample has fewer than
+// two values, ErrZeroVariance if both samples have zero variance, or
+// ErrNoProgress if the total sample size has not increased since the
+// previous look. In these cases, the test is unchanged.
+func (g *GroupSequentialTest) Look(x1, x2 TTestSample) (*GroupSequentialResult, error) {
+	n1, n2 := x1.Weight(), x2.Weight()
+	if n1 <= 1 || n2 <= 1 {
+		return nil, ErrSampleSize
+	}
+	v := x1.Variance()/n1 + x2.Variance()/n2
+	if v == 0 {
+		return nil, ErrZeroVariance
+	}
+	t := math.Min(1, (n1+n2)/g.MaxN)
+	if t <= g.t {
+		return nil, ErrNoProgress
+	}
+	spent := g.Spending.spent(g.Alpha, t)
+
+	// Find the bound c for which the probability under the null
+	// hypothesis of first crossing the boundary at this look is
+	// spent - g.spent. Under the null hypothesis, S is Brownian
+	// motion, so S at this look is S at the previous look plus
+	// independent Normal(0, t - g.t) noise.
+	delta := t - g.t
+	sd := math.Sqrt(delta)
+	cross := func(c float64) float64 {
+		b := c * math.Sqrt(t)
+		if g.grid == nil {
+			return 2 * StdNormal.CDF(-c)
+		}
+		return simpson(g.grid, func(i int, s float64) float64 {
+			return g.density[i] * (StdNormal.CDF((-b-s)/sd) + StdNormal.CDF((s-b)/sd))
+		})
+	}
+	target := spent - g.spent
+	var c float64
+	if target <= 0 || cross(40) >= target {
+		c = inf
+	} else {
+		_, c = bisectBool(func(c float64) bool {
+			return cross(c) > target
+		}, 0, 40, 1e-9)
+	}
+
+	// Update the density of S over the continuation region.
+	b := math.Min(c, 40) * math.Sqrt(t)
+	grid := make([]float64, groupSeqGridSize)
+	density := make([]float64, groupSeqGridSize)
+	for i := range grid {
+		y := -b + 2*b*float64(i)/float64(groupSeqGridSize-1)
+		grid[i] = y
+		if g.grid == nil {
+			density[i] = NormalDist{0, math.Sqrt(t)}.PDF(y)
+		} else {
+			density[i] = simpson(g.grid, func(j int, s float64) float64 {
+				return g.density[j] * NormalDist{s, sd}.PDF(y)
+			})
+		}
+	}
+	g.t, g.spent, g.grid, g.density = t, spent, grid, density
+	g.look++
+
+	z := (x1.Mean() - x2.Mean()) / math.Sqrt(v)
+	return &GroupSequentialResult{
+		Look:     g.look,
+		Fraction: t,
+		Z:        z,
+		Bound:    c,
+		Spent:    spent,
+		Reject:   math.Abs(z) >= c,
+	}, nil
+}
+
+// simpson returns the integral of f over the uniformly spaced points
+// xs using Simpson's rule. len(xs) must be odd. f is passed the index
+// and value of each point.
+func simpson(xs []float64, f func(i int, x float64) float64) float64 {
+	n := len(xs) - 1
+	h := (xs[n] - xs[0]) / float64(n)
+	sum := f(0, xs[0]) + f(n, xs[n])
+	for i := 1; i < n; i++ {
+		if i%2 == 1 {
+			sum += 4 * f(i, xs[i])
+		} else {
+			sum += 2 * f(i, xs[i])
+		}
+	}
+	return sum * h / 3
+}
@@ -0,0 +1,185 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package stats
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+)
+
+// fakeTTestSample is a TTestSample with fixed statistics.
+type fakeTTestSample struct {
+	n, mean, variance float64
+}
+
+func (s fakeTTestSample) Weight() float64   { return s.n }
+func (s fakeTTestSample) Mean() float64     { return s.mean }
+func (s fakeTTestSample) Variance() float64 { return s.variance }
+
+func TestGroupSequentialBounds(t *testing.T) {
+	// Two-sided Lan-DeMets boundaries for five equally spaced
+	// looks at α = 0.05, from R's ldbounds package.
+	for _, test := range []struct {
+		spending AlphaSpending
+		want     []float64
+	}{
+		{SpendingOBrienFleming, []float64{4.8769, 3.3569, 2.6803, 2.2898, 2.0310}},
+		{SpendingPocock, []float64{2.4380, 2.4268, 2.4101, 2.3966, 2.3859}},
+	} {
+		g := NewGroupSequentialTest(0.05, test.spending, 100)
+		for i, want := range test.want {
+			n := float64(10 * (i + 1))
+			res, err := g.Look(fakeTTestSample{n, 0, 1}, fakeTTestSample{n, 0, 1})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if res.Look != i+1 || !aeq(res.Fraction, 2*n/100) {
+				t.Errorf("%v: look %d: got look %d, fraction %v", test.spending, i+1, res.Look, res.Fraction)
+			}
+			if !aeqTol(res.Bound, want, 2e-4) {
+				t.Errorf("%v: look %d: bound %v, want %v", test.spending, i+1, res.Bound, want)
+			}
+		}
+		if !aeq(g.spent, 0.05) {
+			t.Errorf("%v: spent %v, want 0.05", test.spending, g.spent)
+		}
+	}
+
+	// A single look is a fixed-sample z-test.
+	g := NewGroupSequentialTest(0.05, SpendingOBrienFleming, 10)
+	res, _ := g.Look(fakeTTestSample{5, 1, 1}, fakeTTestSample{5, 0, 1})
+	if !aeqTol(res.Bound, 1.959964, 1e-6) || !aeq(res.Z, math.Sqrt(2.5)) || res.Reject {
+		t.Errorf("single look: got %+v", res)
+	}
+
+	g = NewGroupSequentialTest(0.05, SpendingPocock, 10)
+	g.Look(fakeTTestSample{2, 0, 1}, fakeTTestSample{2, 0, 1})
+	if _, err := g.Look(fakeTTestSample{2, 0, 1}, fakeTTestSample{2, 0, 1}); err != ErrNoProgress {
+		t.Errorf("want ErrNoProgress, got %v", err)
+	}
+}
+
+// sequentialFalsePositives simulates trials runs of a sequential
+// test under the null hypothesis, looking after every batch of 10
+// values in each sample up to 200, and returns the fraction of runs
+// in which reject returned true at any look.
+func sequentialFalsePositives(trials int, newTest func() func(x1, x2 *StreamStats) bool) float64 {
+	r := rand.New(rand.NewSource(1))
+	rejects := 0
+	for i := 0; i < trials; i++ {
+		reject := newTest()
+		var x1, x2 StreamStats
+		for n := 0; n < 200; n += 10 {
+			for j := 0; j < 10; j++ {
+				x1.Add(r.NormFloat64())
+				x2.Add(r.NormFloat64())
+			}
+			if reject(&x1, &x2) {
+				rejects++
+				break
+			}
+		}
+	}
+	return float64(rejects) / float64(trials)
+}
+
+func TestSequentialFalsePositives(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping simulation in short mode")
+	}
+	const trials = 2000
+	// Peeking with a t-test is invalid.
+	fp := sequentialFalsePositives(trials, func() func(x1, x2 *StreamStats) bool {
+		return func(x1, x2 *StreamStats) bool {
+			res, _ := TwoSampleWelchTTest(x1, x2, LocationDiffers)
+			return res.P < 0.05
+		}
+	})
+	if fp < 0.1 {
+		t.Errorf("t-test with peeking: false positive rate %v, want > 0.1", fp)
+	}
+
+	// The standard error of the false positive rate is about
+	// 0.005, and the sequential tests are somewhat conservative.
+	fp = sequentialFalsePositives(trials, func() func(x1, x2 *StreamStats) bool {
+		m := NewMSPRT(0.3)
+		return func(x1, x2 *StreamStats) bool {
+			p, _ := m.Update(x1, x2)
+			return p < 0.05
+		}
+	})
+	if fp > 0.06 {
+		t.Errorf("mSPRT: false positive rate %v, want <= 0.05", fp)
+	}
+	for _, spending := range []AlphaSpending{SpendingOBrienFleming, SpendingPocock} {
+		// The bounds depend only on the sample sizes at each
+		// look, so compute them once.
+		g := NewGroupSequentialTest(0.05, spending, 400)
+		var bounds []float64
+		for n := 10.0; n <= 200; n += 10 {
+			res, _ := g.Look(fakeTTestSample{n, 0, 1}, fakeTTestSample{n, 0, 1})
+			bounds = append(bounds, res.Bound)
+		}
+		fp = sequentialFalsePositives(trials, func() func(x1, x2 *StreamStats) bool {
+			look := 0
+			return func(x1, x2 *StreamStats) bool {
+				z := (x1.Mean() - x2.Mean()) / math.Sqrt(x1.Variance()/x1.Weight()+x2.Variance()/x2.Weight())
+				look++
+				return math.Abs(z) >= bounds[look-1]
+			}
+		})
+		if fp < 0.035 || fp > 0.065 {
+			t.Errorf("%v: false positive rate %v, want ~0.05", spending, fp)
+		}
+	}
+}
+
+func TestMSPRT(t *testing.T) {
+	m := NewMSPRT(1)
+	if lo, hi := m.CI(0.95); !math.IsInf(lo, -1) || !math.IsInf(hi, 1) {
+		t.Errorf("initial CI = [%v, %v], want (-Inf, +Inf)", lo, hi)
+	}
+	if _, err := m.Update(fakeTTestSample{1, 0, 0}, fakeTTestSample{5, 0, 1}); err != ErrSampleSize {
+		t.Errorf("want ErrSampleSize, got %v", err)
+	}
+
+	// With Var = Tau² = 1, the likelihood ratio is
+	// exp(Diff²/4)/√2.
+	p, err := m.Update(fakeTTestSample{2, 3, 1}, fakeTTestSample{2, 0, 1})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := math.Sqrt2 * math.Exp(-9.0/4); !aeq(p, want) {
+		t.Errorf("P = %v, want %v", p, want)
+	}
+	// The CI excludes 0 exactly when P < α.
+	lo1, _ := m.CI(1 - p*1.001)
+	lo2, _ := m.CI(1 - p*0.999)
+	if !(lo1 > 0 && lo2 < 0) {
+		t.Errorf("CI at confidence 1-P does not have 0 on its boundary")
+	}
+
+	// The p-value never increases.
+	if p2, _ := m.Update(fakeTTestSample{4, 0, 1}, fakeTTestSample{4, 0, 1}); p2 != p {
+		t.Errorf("P increased from %v to %v", p, p2)
+	}
+
+	// A real difference is eventually detected.
+	r := rand.New(rand.NewSource(1))
+	var x1, x2 StreamStats
+	m = NewMSPRT(0.5)
+	for i := 0; i < 500; i++ {
+		x1.Add(r.NormFloat64() + 0.5)
+		x2.Add(r.NormFloat64())
+		m.Update(&x1, &x2)
+	}
+	if m.P > 0.001 {
+		t.Errorf("P = %v for difference of 0.5 after 500 samples", m.P)
+	}
+	if lo, hi := m.CI(0.95); !(lo < 0.5 && 0.5 < hi && lo > 0) {
+		t.Errorf("CI = [%v, %v], want to contain 0.5 and exclude 0", lo, hi)
+	}
+}
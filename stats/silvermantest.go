@@ -0,0 +1,206 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package stats
+
+import (
+	"math"
+	"math/rand"
+	"sort"
+
+	"github.com/aclements/go-moremath/vec"
+)
+
+// A SilvermanTestResult is the result of Silverman's test for
+// multimodality.
+type SilvermanTestResult struct {
+	// N is the size of the sample.
+	N int
+
+	// Modes is the number of modes k under the null hypothesis.
+	Modes int
+
+	// Bandwidth is the critical bandwidth of the sample for Modes
+	// modes. This is the smallest bandwidth for which a Gaussian
+	// KDE of the sample has at most Modes modes.
+	Bandwidth float64
+
+	// P is the p-value of the test for the null hypothesis that
+	// the sample was drawn from a distribution with at most
+	// Modes modes.
+	P float64
+}
+
+// SilvermanTestReplicates is the number of smoothed bootstrap
+// samples drawn by SilvermanTest to estimate its p-value.
+var SilvermanTestReplicates = 500
+
+// silvermanGridPoints is the number of points at which a KDE is
+// evaluated to count its modes.
+const silvermanGridPoints = 256
+
+// KDEModes returns the number of modes of the PDF of kde. It counts
+// the local maxima of the PDF evaluated on an evenly spaced grid
+// over kde.Bounds(), so it may miss modes that are very close
+// together.
+func KDEModes(kde *KDE) int {
+	lo, hi := kde.Bounds()
+	return countModes(vec.Map(kde.PDF, vec.Linspace(lo, hi, silvermanGridPoints)))
+}
+
+// countModes returns the number of local maxima in ys, treating runs
+// of equal values as a single point.
+func countModes(ys []float64) int {
+	modes, rising := 0, true
+	for i := 1; i < len(ys); i++ {
+		if ys[i] > ys[i-1] {
+			rising = true
+		} else if ys[i] < ys[i-1] {
+			if rising {
+				modes++
+			}
+			rising = false
+		}
+	}
+	if rising && len(ys) > 0 {
+		modes++
+	}
+	return modes
+}
+
+// gaussianKDEModes returns the number of modes of a Gaussian KDE of
+// s with bandwidth h. Unlike KDEModes, this evaluates the KDE on a
+// fixed grid that covers s and the tails of the kernel, so that the
+// mode count is monotonic in h.
+func gaussianKDEModes(s Sample, h float64) int {
+	lo, hi := s.Bounds()
+	kde := &KDE{Sample: s, Kernel: GaussianKernel, Bandwidth: h}
+	xs := vec.Linspace(lo-3*h, hi+3*h, silvermanGridPoints)
+	return countModes(vec.Map(kde.PDF, xs))
+}
+
+// CriticalBandwidth returns the critical bandwidth of s for k modes.
+// This is the smallest bandwidth h for which a Gaussian KDE of s
+// with bandwidth h has at most k modes.
+//
+// The number of modes of a Gaussian KDE is a non-increasing function
+// of the bandwidth [1], so this is found by bisection.
+//
+// [1] Silverman, B. W. (1981). "Using Kernel Density Estimates to
+// Investigate Multimodality". Journal of the Royal Statistical
+// Society B 43 (1): 97-99.
+func CriticalBandwidth(s Sample, k int) float64 {
+	if k < 1 {
+		panic("k must be >= 1")
+	}
+	lo, hi := s.Bounds()
+	if math.IsNaN(lo) || lo == hi {
+		return 0
+	}
+
+	// With a bandwidth as large as the range of the data, the
+	// KDE is unimodal. Shrink the low bound until it has too
+	// many modes.
+	hHi := hi - lo
+	hLo := hHi / 2
+	for gaussianKDEModes(s, hLo) <= k {
+		hHi = hLo
+		hLo /= 2
+		if hLo < (hi-lo)*1e-9 {
+			// There aren't k+1 distinguishable modes at
+			// any bandwidth.
+			return hLo
+		}
+	}
+
+	// Bisect to a relative tolerance.
+	_, h := bisectBool(func(h float64) bool {
+		return gaussianKDEModes(s, h) > k
+	}, hLo, hHi, hLo*1e-4)
+	return h
+}
+
+// SilvermanTest performs Silverman's bandwidth test [1] of the null
+// hypothesis that s was drawn from a distribution with at most k
+// modes against the alternative hypothesis that the distribution has
+// more than k modes. In particular, SilvermanTest(s, 1, r) tests for
+// multimodality.
+//
+// The test statistic is the critical bandwidth of s for k modes (see
+// CriticalBandwidth). A large critical bandwidth indicates that the
+// data must be smoothed heavily to eliminate extra modes. The
+// p-value is computed using a smoothed bootstrap with variance
+// correction: SilvermanTest draws SilvermanTestReplicates samples
+// from the Gaussian KDE of s at the critical bandwidth, and counts
+// the samples whose KDE at the critical bandwidth has more than k
+// modes. The p-value is (count+1)/(SilvermanTestReplicates+1), so it
+// is never 0. Bootstrap samples are drawn from r; if r is nil, it
+// uses the default global source.
+//
+// This test is known to be conservative [2].
+//
+// This can fail with ErrSampleSize if s has fewer than 3 values or
+// ErrSamplesEqual if all values of s are equal.
+//
+// [1] Silverman, B. W. (1981). "Using Kernel Density Estimates to
+// Investigate Multimodality". Journal of the Royal Statistical
+// Society B 43 (1): 97-99.
+//
+// [2] Hall, P.; York, M. (2001). "On the Calibration of Silverman's
+// Test for Multimodality". Statistica Sinica 11: 515-536.
+func SilvermanTest(s Sample, k int, r *rand.Rand) (*SilvermanTestResult, error) {
+	if len(s.Xs) < 3 {
+		return nil, ErrSampleSize
+	}
+	lo, hi := s.Bounds()
+	if lo == hi {
+		return nil, ErrSamplesEqual
+	}
+
+	h := CriticalBandwidth(s, k)
+
+	unif, norm := rand.Float64, rand.NormFloat64
+	if r != nil {
+		unif, norm = r.Float64, r.NormFloat64
+	}
+
+	// Prepare to resample s with its weights.
+	var cum []float64
+	if s.Weights != nil {
+		cum = make([]float64, len(s.Weights))
+		sum := 0.0
+		for i, w := range s.Weights {
+			sum += w
+			cum[i] = sum
+		}
+	}
+	pick := func() float64 {
+		if cum == nil {
+			return s.Xs[int(unif()*float64(len(s.Xs)))]
+		}
+		u := unif() * cum[len(cum)-1]
+		return s.Xs[sort.Search(len(cum)-1, func(i int) bool { return cum[i] > u })]
+	}
+
+	// The smoothed bootstrap inflates the variance of the sample
+	// by h². Following Efron and Silverman, shrink the resampled
+	// values toward the mean to correct for this.
+	mean, variance := s.Mean(), s.Variance()
+	scale := 1 / math.Sqrt(1+h*h/variance)
+
+	count := 0
+	boot := Sample{Xs: make([]float64, len(s.Xs))}
+	for rep := 0; rep < SilvermanTestReplicates; rep++ {
+		for i := range boot.Xs {
+			y := pick() + h*norm()
+			boot.Xs[i] = mean + (y-mean)*scale
+		}
+		if gaussianKDEModes(boot, h) > k {
+			count++
+		}
+	}
+	p := float64(count+1) / float64(SilvermanTestReplicates+1)
+
+	return &SilvermanTestResult{N: len(s.Xs), Modes: k, Bandwidth: h, P: p}, nil
+}
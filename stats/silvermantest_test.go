@@ -0,0 +1,86 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package stats
+
+import (
+	"math/rand"
+	"testing"
+)
+
+func TestCountModes(t *testing.T) {
+	for _, test := range []struct {
+		ys   []float64
+		want int
+	}{
+		{nil, 0},
+		{[]float64{1}, 1},
+		{[]float64{1, 2, 3}, 1},
+		{[]float64{3, 2, 1}, 1},
+		{[]float64{1, 2, 1}, 1},
+		{[]float64{1, 2, 2, 1}, 1},
+		{[]float64{1, 2, 1, 2, 1}, 2},
+		{[]float64{2, 1, 2}, 2},
+		{[]float64{1, 1, 1}, 1},
+	} {
+		if got := countModes(test.ys); got != test.want {
+			t.Errorf("countModes(%v): want %d, got %d", test.ys, test.want, got)
+		}
+	}
+}
+
+func TestSilvermanTest(t *testing.T) {
+	defer func(old int) { SilvermanTestReplicates = old }(SilvermanTestReplicates)
+	SilvermanTestReplicates = 100
+
+	r := rand.New(rand.NewSource(1))
+	var uni, bi []float64
+	for i := 0; i < 100; i++ {
+		uni = append(uni, r.NormFloat64())
+		bi = append(bi, r.NormFloat64(), r.NormFloat64()+4)
+	}
+
+	check := func(s Sample, k int, multi bool) *SilvermanTestResult {
+		t.Helper()
+		res, err := SilvermanTest(s, k, r)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if res.Modes != k || res.N != len(s.Xs) {
+			t.Errorf("bad result %+v", res)
+		}
+		if multi && res.P > 0.01 {
+			t.Errorf("want more than %d modes, got %+v", k, res)
+		} else if !multi && res.P < 0.1 {
+			t.Errorf("want at most %d modes, got %+v", k, res)
+		}
+		if min := 1 / float64(SilvermanTestReplicates+1); res.P < min {
+			t.Errorf("want p-value at least %v, got %v", min, res.P)
+		}
+
+		// The KDE at the critical bandwidth should have k
+		// modes, and just below it should have more.
+		h := res.Bandwidth
+		if got := gaussianKDEModes(s, h); got > k {
+			t.Errorf("KDE at critical bandwidth %v has %d > %d modes", h, got, k)
+		}
+		if got := gaussianKDEModes(s, h*0.99); got <= k {
+			t.Errorf("KDE below critical bandwidth %v has %d <= %d modes", h, got, k)
+		}
+		return res
+	}
+	check(Sample{Xs: uni}, 1, false)
+	res := check(Sample{Xs: bi}, 1, true)
+	check(Sample{Xs: bi}, 2, false)
+
+	// Unit weights should give the same critical bandwidth.
+	ws := make([]float64, len(bi))
+	for i := range ws {
+		ws[i] = 1
+	}
+	res2 := check(Sample{Xs: bi, Weights: ws}, 1, true)
+	if res.Bandwidth != res2.Bandwidth {
+		t.Errorf("weighted critical bandwidth %v != unweighted %v", res2.Bandwidth, res.Bandwidth)
+	}
+}
@@ -6,6 +6,7 @@ package stats
 
 import (
 	"math"
+	"math/rand"
 
 	"github.com/aclements/go-moremath/mathx"
 )
@@ -40,3 +41,35 @@ func (t TDist) CDF(x float64) float64 {
 func (t TDist) Bounds() (float64, float64) {
 	return -4, 4
 }
+
+func (t TDist) Rand(r *rand.Rand) float64 {
+	var z float64
+	if r == nil {
+		z = rand.NormFloat64()
+	} else {
+		z = r.NormFloat64()
+	}
+	// A chi-squared variate with V degrees of freedom is
+	// 2*Gamma(V/2).
+	return z / math.Sqrt(2*randGamma(r, t.V/2)/t.V)
+}
+
+// Mean returns the mean of t, which is 0 if V > 1 and undefined
+// (NaN) otherwise.
+func (t TDist) Mean() float64 {
+	if t.V > 1 {
+		return 0
+	}
+	return nan
+}
+
+// Variance returns the variance of t, which is V/(V-2) if V > 2,
+// infinite if 1 < V <= 2, and undefined (NaN) otherwise.
+func (t TDist) Variance() float64 {
+	if t.V > 2 {
+		return t.V / (t.V - 2)
+	} else if t.V > 1 {
+		return inf
+	}
+	return nan
+}
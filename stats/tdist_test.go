@@ -4,7 +4,10 @@
 
 package stats
 
-import "testing"
+import (
+	"fmt"
+	"testing"
+)
 
 func TestT(t *testing.T) {
 	testFunc(t, "PDF(%v|v=1)", TDist{1}.PDF, map[float64]float64{
@@ -93,3 +96,10 @@ func TestT(t *testing.T) {
 		8:   0.99975354666971372,
 		9:   0.9998586600128780})
 }
+
+func TestTDistRand(t *testing.T) {
+	for _, v := range []float64{0.5, 1, 5, 30} {
+		d := TDist{v}
+		testRandQuantiles(t, fmt.Sprintf("%+v", d), d, d.Rand)
+	}
+}
@@ -26,6 +26,18 @@ import (
 // the latter paper but has mathematical typesetting issues, so it's
 // easiest to get the context from the former paper and the details
 // from the latter).
+//
+// UDist computes the distribution without ties using the generating
+// function method of Harding, E. F. (1984). "An Efficient,
+// Minimal-storage Procedure for Calculating the Mann-Whitney U,
+// Generalized U and Similar Distributions". Journal of the Royal
+// Statistical Society, Series C 33 (1): 1-6. With ties, it uses a
+// variant of the shift algorithm of Streitberg, Bernd; Röhmel, Joachim
+// (1986). "Exact distributions for permutation and rank tests: An
+// introduction to some recently published algorithms". Statistical
+// Software Newsletter 12 (1): 10-17. In both cases, it uses the
+// symmetries of U to compute only the smaller tail of the
+// distribution.
 type UDist struct {
 	N1, N2 int
 
@@ -46,280 +58,202 @@ func (d UDist) hasTies() bool {
 	return false
 }
 
-// p returns the p_{d.N1,d.N2} function defined by Mann, Whitney 1947
-// for values of U from 0 up to and including the U argument.
+// p returns the PMF of U for values of U from 0 up to and including
+// the U argument, assuming there are no ties.
 //
-// This algorithm runs in Θ(N1*N2*U) = O(N1²N2²) time and is quite
-// fast for small values of N1 and N2. However, it does not handle ties.
+// This algorithm runs in Θ(min(N1,N2)*U) time and Θ(U) space.
 func (d UDist) p(U int) []float64 {
-	// This is a dynamic programming implementation of the
-	// recursive recurrence definition given by Mann and Whitney:
-	//
-	//   p_{n,m}(U) = (n * p_{n-1,m}(U-m) + m * p_{n,m-1}(U)) / (n+m)
-	//   p_{n,m}(U) = 0                           if U < 0
-	//   p_{0,m}(U) = p{n,0}(U) = 1 / nCr(m+n, n) if U = 0
-	//                          = 0               if U > 0
-	//
-	// (Note that there is a typo in the original paper. The first
-	// recursive application of p should be for U-m, not U-M.)
-	//
-	// Since p{n,m} only depends on p{n-1,m} and p{n,m-1}, we only
-	// need to store one "plane" of the three dimensional space at
-	// a time.
+	// The number of arrangements of the two samples with a given
+	// U is the coefficient of q^U in the Gaussian binomial
+	// coefficient
 	//
-	// Furthermore, p_{n,m} = p_{m,n}, so we only construct values
-	// for n <= m and obtain the rest through symmetry.
+	//   [n+m choose n]_q = ∏_{i=1}^{n} (1 - q^(m+i)) / (1 - q^i).
 	//
-	// We organize the computed values of p as followed:
+	// Following Harding, we build this product one factor at a
+	// time in a single array of coefficients. Multiplying by
+	// 1 - q^(m+i) and dividing by 1 - q^i (a running sum with
+	// stride i) only ever move coefficients to higher powers of q,
+	// so we only need to keep the coefficients up to q^U.
 	//
-	//       n →   N
-	//     m *
-	//     ↓ * *
-	//       * * *
-	//       * * * *
-	//       * * * *
-	//     M * * * *
-	//
-	// where each * is a slice indexed by U. The code below
-	// computes these left-to-right, top-to-bottom, so it only
-	// stores one row of this matrix at a time. Furthermore,
-	// computing an element in a given U slice only depends on the
-	// same and smaller values of U, so we can overwrite the U
-	// slice we're computing in place as long as we start with the
-	// largest value of U. Finally, even though the recurrence
-	// depends on (n,m) above the diagonal and we use symmetry to
-	// mirror those across the diagonal to (m,n), the mirrored
-	// indexes are always available in the current row, so this
-	// mirroring does not interfere with our ability to recycle
-	// state.
-
-	N, M := d.N1, d.N2
-	if N > M {
-		N, M = M, N
-	}
-
-	memo := make([][]float64, N+1)
-	for n := range memo {
-		memo[n] = make([]float64, U+1)
+	// After factor i, the coefficients sum to nCr(m+i, i). To
+	// avoid overflow for large samples, we rescale after each
+	// factor so they sum to 1 and hence are probabilities.
+	n, m := d.N1, d.N2
+	if n > m {
+		n, m = m, n
 	}
 
-	for m := 0; m <= M; m++ {
-		// Compute p_{0,m}. This is zero except for U=0.
-		memo[0][0] = 1
-
-		// Compute the remainder of this row.
-		nlim := N
-		if m < nlim {
-			nlim = m
+	p := make([]float64, U+1)
+	p[0] = 1
+	for i := 1; i <= n; i++ {
+		// Multiply by 1 - q^(m+i).
+		for u := U; u >= m+i; u-- {
+			p[u] -= p[u-m-i]
 		}
-		for n := 1; n <= nlim; n++ {
-			lp := memo[n-1] // p_{n-1,m}
-			var rp []float64
-			if n <= m-1 {
-				rp = memo[n] // p_{n,m-1}
-			} else {
-				rp = memo[m-1] // p{m-1,n} and m==n
-			}
-
-			// For a given n,m, U is at most n*m.
-			//
-			// TODO: Actually, it's at most ⌈n*m/2⌉, but
-			// then we need to use more complex symmetries
-			// in the inner loop below.
-			ulim := n * m
-			if U < ulim {
-				ulim = U
-			}
-
-			out := memo[n] // p_{n,m}
-			nplusm := float64(n + m)
-			for U1 := ulim; U1 >= 0; U1-- {
-				l := 0.0
-				if U1-m >= 0 {
-					l = float64(n) * lp[U1-m]
-				}
-				r := float64(m) * rp[U1]
-				out[U1] = (l + r) / nplusm
-			}
+		// Divide by 1 - q^i.
+		for u := i; u <= U; u++ {
+			p[u] += p[u-i]
+		}
+		scale := float64(i) / float64(m+i)
+		for u := range p {
+			p[u] *= scale
 		}
 	}
-	return memo[N]
-}
-
-type ukey struct {
-	n1   int // size of first sample
-	twoU int // 2*U statistic for this permutation
+	return p
 }
 
-// This computes the cumulative counts of the Mann-Whitney U
-// distribution in the presence of ties using the computation from
-// Cheung, Ying Kuen; Klotz, Jerome H. (1997). "The Mann Whitney
-// Wilcoxon Distribution Using Linked Lists". Statistica Sinica 7:
-// 805-813, with much guidance from appendix L of Klotz, A
-// Computational Approach to Statistics.
+// twoUDist returns Pr[2U = twoU] and Pr[2U <= twoU], taking into
+// account ties. If mirror is true, it instead returns these for 2U
+// under the reversed tie vector, which is the distribution of
+// 2*N1*N2 - 2U.
 //
-// makeUmemo constructs a table memo[K][ukey{n1, 2*U}], where K is the
-// number of ranks (up to len(t)), n1 is the size of the first sample
-// (up to the n1 argument), and U is the U statistic (up to the
-// argument twoU/2). The value of an entry in the memo table is the
-// number of permutations of a sample of size n1 in a ranking with tie
-// vector t[:K] having a U statistic <= U.
-func makeUmemo(twoU, n1 int, t []int) []map[ukey]float64 {
-	// Another candidate for a fast implementation is van de Wiel,
-	// "The split-up algorithm: a fast symbolic method for
-	// computing p-values of distribution-free statistics". This
-	// is what's used by R's coin package. It's a comparatively
-	// recent publication, so it's presumably faster (or perhaps
-	// just more general) than previous techniques, but I can't
-	// get my hands on the paper.
-	//
-	// TODO: ~40% of this function's time is spent in mapassign on
-	// the assignment lines in the two loops and another ~20% in
-	// map access and iteration. Improving map behavior or
-	// replacing the maps altogether with some other constant-time
-	// structure could double performance.
-	//
-	// TODO: The worst case for this function is when there are
-	// few ties. Yet the best case overall is when there are *no*
-	// ties. Can we get the best of both worlds? Use the fast
-	// algorithm for the most part when there are few ties and mix
-	// in the general algorithm just where we need it? That's
-	// certainly possible for sub-problems where t[:k] has no
-	// ties, but that doesn't help if t[0] has a tie but nothing
-	// else does. Is it possible to rearrange the ranks without
-	// messing up our computation of the U statistic for
-	// sub-problems?
-
-	K := len(t)
-
-	// Compute a coefficients. The a slice is indexed by k (a[0]
-	// is unused).
-	a := make([]int, K+1)
-	a[1] = t[0]
-	for k := 2; k <= K; k++ {
-		a[k] = a[k-1] + t[k-2] + t[k-1]
+// See uTiesDist for the cost of this algorithm.
+func (d UDist) twoUDist(twoU int, mirror bool) (pmf, cdf float64) {
+	// U1 = N1*N2 - U2, so the distribution of U for the first
+	// sample with tie vector T is the distribution of U for the
+	// second sample with T reversed. We use whichever sample is
+	// smaller to minimize the state.
+	n, rev := d.N1, mirror
+	if d.N2 < n {
+		n, rev = d.N2, !mirror
 	}
+	t := d.T
+	if rev {
+		t = make([]int, len(d.T))
+		for i, tk := range d.T {
+			t[len(t)-1-i] = tk
+		}
+	}
+	return uTiesDist(n, t, twoU)
+}
 
-	// Create the memo table for the counts function, A. The A
-	// slice is indexed by k (A[0] is unused).
-	//
-	// In "The Mann Whitney Distribution Using Linked Lists", they
-	// use linked lists (*gasp*) for this, but within each K it's
-	// really just a memoization table, so it's faster to use a
-	// map. The outer structure is a slice indexed by k because we
-	// need to find all memo entries with certain values of k.
+// uTiesDist returns Pr[2U = twoU] and Pr[2U <= twoU] for a sample of
+// size n, ranked together with m = sum(t)-n other values with tie
+// vector t.
+//
+// For twoU <= n*m, this algorithm runs in O(sum(t)*n*twoU) time and
+// O(n*twoU) space, but the constant factors are small: at the
+// median, twoU = n*m, it keeps about n²m/4 values of state.
+func uTiesDist(n int, t []int, twoU int) (pmf, cdf float64) {
+	// This processes the tie groups in rank order. The state P
+	// is indexed by r and s, where P[r][s] is the probability
+	// that a random r-subset of the values in the groups so far
+	// has a (partial) 2U statistic of s. Adding rk values from
+	// the next group increases s by rk*(2*(cum-r)+tk-rk), where
+	// cum is the number of values before the group and tk is the
+	// size of the group.
 	//
-	// TODO: The n1 and twoU values in the ukeys follow strict
-	// patterns. For each K value, the n1 values are every integer
-	// between two bounds. For each (K, n1) value, the twoU values
-	// are every integer multiple of a certain base between two
-	// bounds. It might be worth turning these into directly
-	// indexible slices.
-	A := make([]map[ukey]float64, K+1)
-	A[K] = map[ukey]float64{ukey{n1: n1, twoU: twoU}: 0}
-
-	// Compute memo table (k, n1, twoU) triples from high K values
-	// to low K values. This drives the recurrence relation
-	// downward to figure out all of the needed argument triples.
+	// Each of the n-r values still to be drawn is larger than
+	// the j = cum-r values not drawn so far and than at most all
+	// m values not drawn, so s will increase by between
+	// 2*(n-r)*j and 2*(n-r)*m. Hence, states with s greater than
+	// twoU-2*(n-r)*j can never contribute, and states with s less
+	// than twoU-2*(n-r)*m always end up with 2U < twoU, so we can
+	// add their probability to cdf and discard them. Together
+	// with the largest s possible for an r-subset, 2*r*j, this
+	// limits each P[r] to a band [sLo(r), sHi(r, j)] that is much
+	// smaller than twoU in the interesting cases.
 	//
-	// TODO: Is it possible to generate this table bottom-up? If
-	// so, this could be a pure dynamic programming algorithm and
-	// we could discard the K dimension. We could at least store
-	// the inputs in a more compact representation that replaces
-	// the twoU dimension with an interval and a step size (as
-	// suggested by Cheung, Klotz, not that they make it at all
-	// clear *why* they're suggesting this).
-	tsum := sumint(t) // always ∑ t[0:k]
-	for k := K - 1; k >= 2; k-- {
-		tsum -= t[k]
-		A[k] = make(map[ukey]float64)
-
-		// Construct A[k] from A[k+1].
-		for A_kplus1 := range A[k+1] {
-			rkLow := maxint(0, A_kplus1.n1-tsum)
-			rkHigh := minint(A_kplus1.n1, t[k])
-			for rk := rkLow; rk <= rkHigh; rk++ {
-				twoU_k := A_kplus1.twoU - rk*(a[k+1]-2*A_kplus1.n1+rk)
-				n1_k := A_kplus1.n1 - rk
-				if twoUmin(n1_k, t[:k], a) <= twoU_k && twoU_k <= twoUmax(n1_k, t[:k], a) {
-					key := ukey{n1: n1_k, twoU: twoU_k}
-					A[k][key] = 0
-				}
-			}
+	// Each step only depends on the previous step, and P[r] only
+	// depends on P[r'] for r' <= r, so we can update P in place
+	// by computing it from high r to low r.
+	if n == 0 {
+		if twoU == 0 {
+			return 1, 1
 		}
+		return 0, 1
 	}
-
-	// Fill counts in memo table from low K values to high K
-	// values. This unwinds the recurrence relation.
-
-	// Start with K==2 base case.
-	//
-	// TODO: Later computations depend on these, but these don't
-	// depend on anything (including each other), so if K==2, we
-	// can skip the memo table altogether.
-	if K < 2 {
-		panic("K < 2")
+	N := sumint(t)
+	m := N - n
+	sLo := func(r int) int {
+		return maxint(0, twoU-2*(n-r)*m)
 	}
-	N_2 := t[0] + t[1]
-	for A_2i := range A[2] {
-		Asum := 0.0
-		r2Low := maxint(0, A_2i.n1-t[0])
-		r2High := (A_2i.twoU - A_2i.n1*(t[0]-A_2i.n1)) / N_2
-		for r2 := r2Low; r2 <= r2High; r2++ {
-			Asum += mathx.Choose(t[0], A_2i.n1-r2) *
-				mathx.Choose(t[1], r2)
-		}
-		A[2][A_2i] = Asum
+	sHi := func(r, j int) int {
+		return minint(minint(twoU, 2*r*j), twoU-2*(n-r)*j)
 	}
 
-	// Derive counts for the rest of the memo table.
-	tsum = t[0] // always ∑ t[0:k-1]
-	for k := 3; k <= K; k++ {
-		tsum += t[k-2]
+	// Allocate each row for the widest its band gets. Over j,
+	// sHi(r, j) is largest near where 2*r*j = twoU-2*(n-r)*j.
+	off := make([]int, n+2)
+	for r := 0; r <= n; r++ {
+		j := minint(m, twoU/(2*n))
+		hi := maxint(sHi(r, j), sHi(r, minint(m, j+1)))
+		off[r+1] = off[r] + maxint(0, hi-sLo(r)+1)
+	}
+	P := make([]float64, off[n+1])
+	row := func(r int) []float64 {
+		return P[off[r]:off[r+1]]
+	}
+	// top[r] is the index in row(r) one past the last valid
+	// value. Values at or beyond top[r] may be stale.
+	top := make([]int, n+1)
+	if sLo(0) == 0 && len(row(0)) > 0 {
+		row(0)[0], top[0] = 1, 1
+	}
 
-		// Compute A[k] counts from A[k-1] counts.
-		for A_ki := range A[k] {
-			Asum := 0.0
-			rkLow := maxint(0, A_ki.n1-tsum)
-			rkHigh := minint(A_ki.n1, t[k-1])
-			for rk := rkLow; rk <= rkHigh; rk++ {
-				twoU_kminus1 := A_ki.twoU - rk*(a[k]-2*A_ki.n1+rk)
-				n1_kminus1 := A_ki.n1 - rk
-				x, ok := A[k-1][ukey{n1: n1_kminus1, twoU: twoU_kminus1}]
-				if !ok && twoUmax(n1_kminus1, t[:k-1], a) < twoU_kminus1 {
-					x = mathx.Choose(tsum, n1_kminus1)
+	lnorm := mathx.Lchoose(N, n)
+	cum := 0
+	for _, tk := range t {
+		for r2 := minint(n, cum+tk); r2 >= maxint(0, n-(N-cum-tk)); r2-- {
+			// P[r2] is a mixture of P[r2-rk] over rk, weighted
+			// by the hypergeometric probability of drawing rk
+			// of the r2 values from this group.
+			lo := sLo(r2)
+			hi := sHi(r2, cum+tk-r2)
+			out := row(r2)[:maxint(0, hi-lo+1)]
+			// lrest is the log of the number of ways to
+			// complete an r2-subset to an n-subset, which
+			// turns probabilities in P[r2] into probabilities
+			// of a complete sample.
+			lrest := mathx.Lchoose(N-cum-tk, n-r2) - lnorm
+			lcur := mathx.Lchoose(cum+tk, r2)
+			if r2 <= cum && r2 >= n-(N-cum) {
+				// Start from the rk = 0 term, in place.
+				w0 := math.Exp(mathx.Lchoose(cum, r2) - lcur)
+				valid := minint(top[r2], len(out))
+				for i := range out[:valid] {
+					out[i] *= w0
+				}
+				clear(out[valid:])
+			} else {
+				clear(out)
+			}
+			for rk := 1; rk <= minint(tk, r2); rk++ {
+				r := r2 - rk
+				if r > cum || r < n-(N-cum) {
+					continue
+				}
+				src := row(r)[:top[r]]
+				lw := mathx.Lchoose(tk, rk) + mathx.Lchoose(cum, r)
+				delta := rk * (2*(cum-r) + tk - rk)
+				// Source s maps to out[s+delta-lo]. Values
+				// that land below the band always end up
+				// below twoU.
+				shift := sLo(r) + delta - lo
+				if shift < 0 {
+					below := 0.0
+					for _, p := range src[:minint(len(src), -shift)] {
+						below += p
+					}
+					cdf += below * math.Exp(lw+lrest)
+				}
+				wk := math.Exp(lw - lcur)
+				start, end := maxint(0, shift), minint(len(out), len(src)+shift)
+				if start < end {
+					dst, src := out[start:end], src[start-shift:end-shift]
+					for i := range dst {
+						dst[i] += wk * src[i]
+					}
 				}
-				Asum += x * mathx.Choose(t[k-1], rk)
 			}
-			A[k][A_ki] = Asum
+			top[r2] = len(out)
 		}
+		cum += tk
 	}
-
-	return A
-}
-
-func twoUmin(n1 int, t, a []int) int {
-	K := len(t)
-	twoU := -n1 * n1
-	n1_k := n1
-	for k := 1; k <= K; k++ {
-		twoU_k := minint(n1_k, t[k-1])
-		twoU += twoU_k * a[k]
-		n1_k -= twoU_k
-	}
-	return twoU
-}
-
-func twoUmax(n1 int, t, a []int) int {
-	K := len(t)
-	twoU := -n1 * n1
-	n1_k := n1
-	for k := K; k > 0; k-- {
-		twoU_k := minint(n1_k, t[k-1])
-		twoU += twoU_k * a[k]
-		n1_k -= twoU_k
+	if twoU >= sLo(n) && top[n] > 0 {
+		pmf = row(n)[0]
 	}
-	return twoU
+	return pmf, cdf + pmf
 }
 
 func (d UDist) PMF(U float64) float64 {
@@ -328,19 +262,29 @@ func (d UDist) PMF(U float64) float64 {
 	}
 
 	if d.hasTies() {
-		// makeUmemo computes the CDF directly. Take its
-		// difference to get the PMF.
-		p1, ok1 := makeUmemo(int(2*U)-1, d.N1, d.T)[len(d.T)][ukey{d.N1, int(2*U) - 1}]
-		p2, ok2 := makeUmemo(int(2*U), d.N1, d.T)[len(d.T)][ukey{d.N1, int(2 * U)}]
-		if !ok1 || !ok2 {
-			panic("makeUmemo did not return expected memoization table")
+		// U must be a multiple of 1/2.
+		if math.Floor(2*U) != 2*U {
+			return 0
+		}
+		twoU := int(2 * U)
+		// Use the symmetry between 2U and 2*N1*N2 - 2U to
+		// compute whichever is smaller.
+		if twoU2 := 2*d.N1*d.N2 - twoU; twoU2 < twoU {
+			pmf, _ := d.twoUDist(twoU2, true)
+			return pmf
 		}
-		return (p2 - p1) / mathx.Choose(d.N1+d.N2, d.N1)
+		pmf, _ := d.twoUDist(twoU, false)
+		return pmf
 	}
 
-	// There are no ties. Use the fast algorithm. U must be integral.
+	// There are no ties. Use the fast algorithm. U must be
+	// integral, so the half-integer points have no mass.
+	if math.Floor(2*U)/2 != math.Floor(U) {
+		return 0
+	}
 	Ui := int(math.Floor(U))
-	// TODO: Use symmetry to minimize U
+	// The distribution is symmetric around U = m * n / 2.
+	Ui = minint(Ui, d.N1*d.N2-Ui)
 	return d.p(Ui)[Ui]
 }
 
@@ -352,12 +296,15 @@ func (d UDist) CDF(U float64) float64 {
 	}
 
 	if d.hasTies() {
-		// TODO: Minimize U?
-		p, ok := makeUmemo(int(2*U), d.N1, d.T)[len(d.T)][ukey{d.N1, int(2 * U)}]
-		if !ok {
-			panic("makeUmemo did not return expected memoization table")
+		// Sum up whichever tail is smaller, using the
+		// symmetry between 2U and 2*N1*N2 - 2U.
+		twoU := int(math.Floor(2 * U))
+		if twoU >= d.N1*d.N2 {
+			_, p := d.twoUDist(2*d.N1*d.N2-twoU-1, true)
+			return 1 - p
 		}
-		return p / mathx.Choose(d.N1+d.N2, d.N1)
+		_, p := d.twoUDist(twoU, false)
+		return p
 	}
 
 	// There are no ties. Use the fast algorithm. U must be integral.
@@ -7,6 +7,7 @@ package stats
 import (
 	"fmt"
 	"math"
+	"math/rand"
 	"testing"
 
 	"github.com/aclements/go-moremath/mathx"
@@ -210,6 +211,96 @@ func TestUDistTies(t *testing.T) {
 	checkRef(10, []int{1, 2, 3, 4, 5, 6})
 }
 
+func TestUDistLarge(t *testing.T) {
+	// Without ties, the tie algorithm must agree with the fast
+	// algorithm.
+	n1, n2 := 40, 60
+	ones := make([]int, n1+n2)
+	for i := range ones {
+		ones[i] = 1
+	}
+	noTies := UDist{N1: n1, N2: n2}.p(n1 * n2)
+	cdf := 0.0
+	for U, want := range noTies {
+		cdf += want
+		if U%29 != 0 {
+			continue
+		}
+		pmf, gotCDF := uTiesDist(n1, ones, 2*U)
+		if !aeqTol(pmf, want, 1e-14) {
+			t.Errorf("for U=%d, tie PMF is %v, want %v", U, pmf, want)
+		}
+		if !aeqTol(gotCDF, cdf, 1e-12) {
+			t.Errorf("for U=%d, tie CDF is %v, want %v", U, gotCDF, cdf)
+		}
+		if pmf, _ := uTiesDist(n1, ones, 2*U+1); pmf != 0 {
+			t.Errorf("for U=%d.5, tie PMF is %v, want 0", U, pmf)
+		}
+	}
+
+	// For large samples, the distribution approaches a normal
+	// distribution.
+	n := 300
+	d := UDist{N1: n, N2: n}
+	norm := NormalDist{float64(n*n) / 2, math.Sqrt(float64(n*n*(2*n+1)) / 12)}
+	for _, z := range []float64{-3, -1, 0.5, 2} {
+		U := math.Floor(norm.Mu + z*norm.Sigma)
+		if got, want := d.CDF(U), norm.CDF(U+0.5); math.Abs(got-want) > 1e-3 {
+			t.Errorf("CDF(%v) = %v, want ≈%v", U, got, want)
+		}
+	}
+
+	// The tie algorithm must also agree at the exact limit, where
+	// its state is largest.
+	n1 = MannWhitneyTiesExactLimit
+	ones = make([]int, 2*n1)
+	for i := range ones {
+		ones[i] = 1
+	}
+	noTies = UDist{N1: n1, N2: n1}.p(n1 * n1 / 8)
+	cdf = 0
+	for _, p := range noTies {
+		cdf += p
+	}
+	if pmf, gotCDF := uTiesDist(n1, ones, 2*(n1*n1/8)); !aeqTol(pmf, noTies[n1*n1/8], 1e-12) || !aeqTol(gotCDF, cdf, 1e-12) {
+		t.Errorf("for U=%d, tie PMF and CDF are %v, %v, want %v, %v", n1*n1/8, pmf, gotCDF, noTies[n1*n1/8], cdf)
+	}
+
+	// With heavy ties at the exact limit, the exact test must be
+	// close to the Edgeworth approximation.
+	r := rand.New(rand.NewSource(1))
+	for _, shift := range []float64{0.5, 1} {
+		x1, x2 := make([]float64, n1), make([]float64, n1)
+		for i := range x1 {
+			x1[i] = math.Round(r.NormFloat64() * 4)
+			x2[i] = math.Round(r.NormFloat64()*4 + shift)
+		}
+		exact, _ := MannWhitneyUTest(x1, x2, LocationDiffers)
+		edge, _ := MannWhitneyUTestWith(x1, x2, LocationDiffers, MannWhitneyEdgeworth)
+		if exact.Method != MannWhitneyExact {
+			t.Errorf("want method %v, got %v", MannWhitneyExact, exact.Method)
+		}
+		if math.Abs(exact.P-edge.P) > 1e-2*edge.P {
+			t.Errorf("with shift %v, exact p-value is %v, want ≈%v", shift, exact.P, edge.P)
+		}
+	}
+
+	// The PMF with ties must sum to 1, which requires using both
+	// tails of the distribution.
+	tie := []int{3, 1, 1, 4, 2, 1, 1, 1, 6, 1, 2, 1, 1, 3, 1, 1, 1, 5, 1, 1}
+	dt := UDist{N1: 10, N2: sumint(tie) - 10, T: tie}
+	sum := 0.0
+	for U := 0.0; U <= float64(dt.N1*dt.N2); U += 0.5 {
+		sum += dt.PMF(U)
+		if got, want := dt.CDF(U), sum; !aeqTol(got, want, 1e-12) {
+			t.Errorf("CDF(%v) = %v, want %v", U, got, want)
+		}
+	}
+	if !aeqTol(sum, 1, 1e-12) {
+		t.Errorf("PMF sums to %v, want 1", sum)
+	}
+}
+
 func BenchmarkUDistTies(b *testing.B) {
 	// Worst case: just one tie.
 	n := 20
@@ -224,12 +315,6 @@ func BenchmarkUDistTies(b *testing.B) {
 	}
 }
 
-func XTestPrintUmemo(t *testing.T) {
-	// Reproduce table from Cheung, Klotz.
-	ties := []int{4, 5, 3, 4, 6}
-	printUmemo(makeUmemo(80, 10, ties), ties)
-}
-
 // udistRef computes the PMF and CDF of the U distribution for two
 // samples of sizes n1 and sum(t)-n1 with tie vector t. The returned
 // pmf and cdf are indexed by 2*U.
@@ -311,14 +396,3 @@ enumu:
 	}
 	return
 }
-
-// printUmemo prints the output of makeUmemo for debugging.
-func printUmemo(A []map[ukey]float64, t []int) {
-	fmt.Printf("K\tn1\t2*U\tpr\n")
-	for K := len(A) - 1; K >= 0; K-- {
-		for i, pr := range A[K] {
-			_, ref := udistRef(i.n1, t[:K])
-			fmt.Printf("%v\t%v\t%v\t%v\t%v\n", K, i.n1, i.twoU, pr, ref[i.twoU])
-		}
-	}
-}
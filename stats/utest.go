@@ -71,8 +71,52 @@ type MannWhitneyUTestResult struct {
 	// P is the p-value of the Mann-Whitney test for the given
 	// null hypothesis.
 	P float64
+
+	// Method is the method used to compute P. This is never
+	// MannWhitneyAuto.
+	Method MannWhitneyMethod
 }
 
+// A MannWhitneyMethod specifies how a Mann-Whitney U-test computes
+// its p-value from the U statistic.
+type MannWhitneyMethod int
+
+//go:generate stringer -type=MannWhitneyMethod
+
+const (
+	// MannWhitneyAuto uses MannWhitneyExact if both samples are
+	// no larger than MannWhitneyExactLimit (or
+	// MannWhitneyTiesExactLimit if there are ties) and
+	// MannWhitneyNormalCorrected otherwise.
+	MannWhitneyAuto MannWhitneyMethod = iota
+
+	// MannWhitneyExact uses the exact distribution of U, taking
+	// ties into account. See UDist.
+	MannWhitneyExact
+
+	// MannWhitneyNormal approximates the distribution of U with a
+	// normal distribution with the same mean and (tie-corrected)
+	// variance.
+	MannWhitneyNormal
+
+	// MannWhitneyNormalCorrected is like MannWhitneyNormal, but
+	// also applies a continuity correction of 0.5 to U. This is
+	// generally more accurate than MannWhitneyNormal.
+	MannWhitneyNormalCorrected
+
+	// MannWhitneyEdgeworth refines MannWhitneyNormalCorrected
+	// with an Edgeworth expansion that accounts for the excess
+	// kurtosis of the distribution of U. The distribution of U is
+	// symmetric, so this is the first non-trivial correction
+	// term. This is substantially more accurate in the tails for
+	// moderate sample sizes and heavy ties.
+	//
+	// See Fix, Evelyn; Hodges, J. L. (1955). "Significance
+	// Probabilities of the Wilcoxon Test". Annals of Mathematical
+	// Statistics 26 (2): 301–312.
+	MannWhitneyEdgeworth
+)
+
 // MannWhitneyExactLimit gives the largest sample size for which the
 // exact U distribution will be used for the Mann-Whitney U-test.
 //
@@ -80,19 +124,20 @@ type MannWhitneyUTestResult struct {
 // because the distribution is highly irregular. However, computing
 // the distribution for large sample sizes is both computationally
 // expensive and unnecessary because it quickly approaches a normal
-// approximation. Computing the distribution for two 50 value samples
-// takes a few milliseconds on a 2014 laptop.
-var MannWhitneyExactLimit = 50
+// approximation. Computing the distribution for two 200 value samples
+// takes Θ(N1*N2) space and tens of milliseconds.
+var MannWhitneyExactLimit = 200
 
 // MannWhitneyTiesExactLimit gives the largest sample size for which
 // the exact U distribution will be used for the Mann-Whitney U-test
 // in the presence of ties.
 //
 // Computing this distribution is more expensive than computing the
-// distribution without ties, so this is set lower. Computing this
-// distribution for two 25 value samples takes about ten milliseconds
-// on a 2014 laptop.
-var MannWhitneyTiesExactLimit = 25
+// distribution without ties. Computing this distribution for two 200
+// value samples takes O(min(N1,N2)*N1*N2) time and space, which is up
+// to about 16 MB and a few hundred milliseconds when U is near its
+// median, and much less in the tails.
+var MannWhitneyTiesExactLimit = 200
 
 // MannWhitneyUTest performs a Mann-Whitney U-test [1,2] of the null
 // hypothesis that two samples come from the same population against
@@ -109,7 +154,7 @@ var MannWhitneyTiesExactLimit = 25
 // than MannWhitneyExactLimit if there are no ties or
 // MannWhitneyTiesExactLimit if there are ties. This normal
 // approximation uses both the tie correction and the continuity
-// correction.
+// correction. Use MannWhitneyUTestWith to select a different method.
 //
 // This can fail with ErrSampleSize if either sample is empty or
 // ErrSamplesEqual if all sample values are equal.
@@ -125,6 +170,13 @@ var MannWhitneyTiesExactLimit = 25
 // [2] Klotz, J. H. (1966). "The Wilcoxon, Ties, and the Computer".
 // Journal of the American Statistical Association 61 (315): 772-787.
 func MannWhitneyUTest(x1, x2 []float64, alt LocationHypothesis) (*MannWhitneyUTestResult, error) {
+	return MannWhitneyUTestWith(x1, x2, alt, MannWhitneyAuto)
+}
+
+// MannWhitneyUTestWith is like MannWhitneyUTest, but computes the
+// p-value using the given method. The method actually used is
+// recorded in the result.
+func MannWhitneyUTestWith(x1, x2 []float64, alt LocationHypothesis, method MannWhitneyMethod) (*MannWhitneyUTestResult, error) {
 	n1, n2 := len(x1), len(x2)
 	if n1 == 0 || n2 == 0 {
 		return nil, ErrSampleSize
@@ -164,9 +216,17 @@ func MannWhitneyUTest(x1, x2 []float64, alt LocationHypothesis) (*MannWhitneyUTe
 	U2 := float64(n1*n2) - U1
 	Usmall := math.Min(U1, U2)
 
+	if method == MannWhitneyAuto {
+		if !hasTies && n1 <= MannWhitneyExactLimit && n2 <= MannWhitneyExactLimit ||
+			hasTies && n1 <= MannWhitneyTiesExactLimit && n2 <= MannWhitneyTiesExactLimit {
+			method = MannWhitneyExact
+		} else {
+			method = MannWhitneyNormalCorrected
+		}
+	}
+
 	var p float64
-	if !hasTies && n1 <= MannWhitneyExactLimit && n2 <= MannWhitneyExactLimit ||
-		hasTies && n1 <= MannWhitneyTiesExactLimit && n2 <= MannWhitneyTiesExactLimit {
+	if method == MannWhitneyExact {
 		// Use exact U distribution. U1 will be an integer.
 		if len(T) == 1 {
 			// All values are equal. Test is meaningless.
@@ -198,8 +258,7 @@ func MannWhitneyUTest(x1, x2 []float64, alt LocationHypothesis) (*MannWhitneyUTe
 			p = 1 - dist.CDF(U1-1)
 		}
 	} else {
-		// Use normal approximation (with tie and continuity
-		// correction).
+		// Use normal approximation (with tie correction).
 		t := tieCorrection(T)
 		N := float64(n1 + n2)
 		μ_U := float64(n1*n2) / 2
@@ -208,28 +267,77 @@ func MannWhitneyUTest(x1, x2 []float64, alt LocationHypothesis) (*MannWhitneyUTe
 			return nil, ErrSamplesEqual
 		}
 		numer := U1 - μ_U
-		// Perform continuity correction.
-		switch alt {
-		case LocationDiffers:
-			numer -= mathx.Sign(numer) * 0.5
-		case LocationLess:
-			numer += 0.5
-		case LocationGreater:
-			numer -= 0.5
+		if method != MannWhitneyNormal {
+			// Perform continuity correction.
+			switch alt {
+			case LocationDiffers:
+				numer -= mathx.Sign(numer) * 0.5
+			case LocationLess:
+				numer += 0.5
+			case LocationGreater:
+				numer -= 0.5
+			}
 		}
 		z := numer / σ_U
+		cdf := StdNormal.CDF
+		if method == MannWhitneyEdgeworth {
+			kurt := uKurtosis(n1, n2, T)
+			cdf = func(z float64) float64 {
+				// The CDF of U is approximately
+				// Φ(z) - φ(z) γ₂/24 (z³ - 3z), where
+				// γ₂ is the excess kurtosis.
+				p := StdNormal.CDF(z) - StdNormal.PDF(z)*kurt/24*(z*z*z-3*z)
+				return math.Max(0, math.Min(1, p))
+			}
+		}
 		switch alt {
 		case LocationDiffers:
-			p = 2 * math.Min(StdNormal.CDF(z), 1-StdNormal.CDF(z))
+			p = 2 * math.Min(cdf(z), 1-cdf(z))
 		case LocationLess:
-			p = StdNormal.CDF(z)
+			p = cdf(z)
 		case LocationGreater:
-			p = 1 - StdNormal.CDF(z)
+			p = 1 - cdf(z)
 		}
 	}
 
 	return &MannWhitneyUTestResult{N1: n1, N2: n2, U: U1,
-		AltHypothesis: alt, P: p}, nil
+		AltHypothesis: alt, P: p, Method: method}, nil
+}
+
+// uKurtosis returns the excess kurtosis of the U statistic for
+// samples of size n1 and n2 with tie vector t.
+func uKurtosis(n1, n2 int, t []int) float64 {
+	// U differs from the sum of the ranks of the first sample by
+	// a constant, so they have the same central moments. This
+	// sum is the sum of a sample of size n1 drawn without
+	// replacement from the N mid-ranks. Let dᵢ be the deviations
+	// of the mid-ranks from their mean, Sₖ = ∑ dᵢᵏ, and Pₖ be the
+	// probability that k specific ranks are all in the first
+	// sample. Since S₁ = 0, expanding the powers of the sum gives
+	//
+	//   μ₂ = S₂(P₁ - P₂)
+	//   μ₄ = S₄(P₁ - 7P₂ + 12P₃ - 6P₄) + 3S₂²(P₂ - 2P₃ + P₄).
+	//
+	// Without ties, this reduces to the excess kurtosis given by
+	// Fix and Hodges.
+	N := n1 + n2
+	mid := float64(N+1) / 2
+	var S2, S4 float64
+	rank := 0
+	for _, tk := range t {
+		d := float64(rank) + float64(tk+1)/2 - mid
+		S2 += float64(tk) * d * d
+		S4 += float64(tk) * d * d * d * d
+		rank += tk
+	}
+	var P [5]float64
+	P[0] = 1
+	for k := 1; k <= 4 && k <= n1; k++ {
+		P[k] = P[k-1] * float64(n1-k+1) / float64(N-k+1)
+	}
+	μ2 := S2 * (P[1] - P[2])
+	μ4 := S4*(P[1]-7*P[2]+12*P[3]-6*P[4]) + 3*S2*S2*(P[2]-2*P[3]+P[4])
+	return μ4/(μ2*μ2) - 3
 }
 
 // labeledMerge merges sorted lists x1 and x2 into sorted list merged.
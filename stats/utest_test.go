@@ -4,7 +4,10 @@
 
 package stats
 
-import "testing"
+import (
+	"math"
+	"testing"
+)
 
 func TestMannWhitneyUTest(t *testing.T) {
 	check := func(want, got *MannWhitneyUTestResult) {
@@ -78,3 +81,73 @@ func TestMannWhitneyUTest(t *testing.T) {
 	check3(l1, l1, 125000, 0.5000436801680628, 1, 0.5000436801680628)
 	check3(l1, l3, 134845, 0.0019351907119808942, 0.0038703814239617884, 0.9980659818257166)
 }
+
+func TestMannWhitneyUTestMethods(t *testing.T) {
+	// Without ties, the excess kurtosis of U is given by Fix and
+	// Hodges.
+	for _, n := range [][2]int{{1, 1}, {1, 5}, {3, 7}, {20, 30}} {
+		n1, n2 := n[0], n[1]
+		N := float64(n1 + n2)
+		v := float64(n1*n2) * (N + 1) / 12
+		k4 := -float64(n1*n2) * (N + 1) * (N*N + N - float64(n1*n2)) / 120
+		T := make([]int, n1+n2)
+		for i := range T {
+			T[i] = 1
+		}
+		if got, want := uKurtosis(n1, n2, T), k4/(v*v); !aeq(got, want) {
+			t.Errorf("uKurtosis(%d, %d) = %v, want %v", n1, n2, got, want)
+		}
+	}
+
+	// Compare the approximations to the exact p-value in the
+	// tail, where the Edgeworth expansion should be much more
+	// accurate.
+	for _, off := range []int{13, 17} {
+		x1, x2 := make([]float64, 20), make([]float64, 20)
+		for i := range x1 {
+			x1[i] = float64(2 * i)
+			x2[i] = float64(2*i + off)
+		}
+		relErr := map[MannWhitneyMethod]float64{}
+		var exact float64
+		for _, method := range []MannWhitneyMethod{MannWhitneyExact, MannWhitneyNormalCorrected, MannWhitneyEdgeworth} {
+			r, err := MannWhitneyUTestWith(x1, x2, LocationLess, method)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if r.Method != method {
+				t.Errorf("want method %v, got %v", method, r.Method)
+			}
+			if method == MannWhitneyExact {
+				exact = r.P
+			}
+			relErr[method] = math.Abs(r.P-exact) / exact
+		}
+		if relErr[MannWhitneyEdgeworth] > 0.1 || relErr[MannWhitneyEdgeworth] > relErr[MannWhitneyNormalCorrected]/2 {
+			t.Errorf("for offset %d, Edgeworth relative error is %v; normal relative error is %v", off, relErr[MannWhitneyEdgeworth], relErr[MannWhitneyNormalCorrected])
+		}
+	}
+
+	// Heavy ties.
+	var x1, x2 []float64
+	for i := 0; i < 30; i++ {
+		x1 = append(x1, float64(i%5))
+		x2 = append(x2, float64(i%5+i%2))
+	}
+	exact, _ := MannWhitneyUTestWith(x1, x2, LocationLess, MannWhitneyExact)
+	edge, _ := MannWhitneyUTestWith(x1, x2, LocationLess, MannWhitneyEdgeworth)
+	if math.Abs(edge.P-exact.P) > 0.02*exact.P {
+		t.Errorf("with ties, Edgeworth p-value is %v, want ≈%v", edge.P, exact.P)
+	}
+
+	// Auto selects based on the sample size.
+	r, _ := MannWhitneyUTest(x1, x2, LocationDiffers)
+	if r.Method != MannWhitneyExact {
+		t.Errorf("want method %v, got %v", MannWhitneyExact, r.Method)
+	}
+	big := make([]float64, MannWhitneyTiesExactLimit+1)
+	r, _ = MannWhitneyUTest(big, append(x2, 1), LocationDiffers)
+	if r.Method != MannWhitneyNormalCorrected {
+		t.Errorf("want method %v, got %v", MannWhitneyNormalCorrected, r.Method)
+	}
+}